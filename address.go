@@ -0,0 +1,125 @@
+package net
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Схема адреса TLS сервера в формате URL.
+const schemeTLS = "tls"
+
+// Определение схемы адреса в формате URL, возвращается пустая строка, если адрес указан без схемы.
+// Для TCP/IP, UDP и TLS схема должна заканчиваться "://", для unix сокетов и systemd допускается сокращённая
+// форма "unix:путь" и "systemd:имя".
+func addressScheme(addr string) (ret string) {
+	const (
+		sepScheme = ":"
+		sepURL    = "://"
+	)
+	var n int
+
+	if n = strings.Index(addr, sepScheme); n <= 0 {
+		return
+	}
+	switch ret = strings.ToLower(addr[:n]); ret {
+	case netUnix, netUnixPacket, netSystemd:
+	case netTcp, netTcp4, netTcp6, netUdp, netUdp4, netUdp6, schemeTLS:
+		if !strings.HasPrefix(addr[n:], sepURL) {
+			ret = ""
+		}
+	default:
+		ret = ""
+	}
+
+	return
+}
+
+// Разбор адреса в формате URL. Поддерживаемые форматы:
+// tcp://host:port, tcp4://host:port, tcp6://host:port - TCP/IP сервер;
+// udp://host:port, udp4://host:port, udp6://host:port - UDP сервер, порт заданный синонимом определяется для UDP;
+// unix:///путь?mode=0660, unix:путь, unixpacket:путь   - Unix сокет, mode - файловые разрешения доступа к сокету;
+// unix:@имя                                           - Unix сокет в абстрактном пространстве имён Linux;
+// systemd://имя, systemd:имя                          - Сокет, переданный systemd;
+// tls://host:port?cert=...&key=...&password=...       - TCP/IP сервер с TLS шифрованием, значения параметров
+// задаются так же, как TLSPublicKeyPEM, TLSPrivateKeyPEM и TLSPrivateKeyPassword.
+func parseAddressURL(addr string) (ret *Configuration, err error) {
+	ret, err = addressURLConfiguration(addr)
+	defaultConfiguration(ret)
+
+	return
+}
+
+// Разбор адреса в формате URL в конфигурацию сервера без наполнения значениями по умолчанию.
+func addressURLConfiguration(addr string) (ret *Configuration, err error) {
+	const (
+		keyMode     = "mode"
+		keyCert     = "cert"
+		keyKey      = "key"
+		keyPassword = "password"
+	)
+	var (
+		query  url.Values
+		scheme string
+		body   string
+		host   string
+		port   string
+		n      int
+	)
+
+	// Разбор выполняется без url.Parse, так как url.Parse не допускает порт, заданный синонимом, например ":http".
+	ret, scheme = new(Configuration), addressScheme(addr)
+	body = strings.TrimPrefix(addr[len(scheme)+1:], "//")
+	if n = strings.IndexByte(body, '?'); n >= 0 {
+		if query, err = url.ParseQuery(body[n+1:]); err != nil {
+			err = fmt.Errorf("%w %q: %s", Errors().AddressURL(), addr, err)
+			return
+		}
+		body = body[:n]
+	}
+	switch scheme {
+	case netUnix, netUnixPacket, netSystemd:
+		ret.Mode, ret.Socket = scheme, body
+		if scheme == netSystemd {
+			ret.Socket = strings.Trim(ret.Socket, "/")
+		}
+		if ret.SocketMode = query.Get(keyMode); ret.SocketMode != "" {
+			if _, err = strconv.ParseUint(ret.SocketMode, 8, 32); err != nil {
+				err = fmt.Errorf("%w %q: %s", Errors().AddressURL(), addr, err)
+				return
+			}
+		}
+		if ret.Socket == "" && scheme != netSystemd {
+			err = fmt.Errorf("%w %q", Errors().AddressURL(), addr)
+			return
+		}
+	default:
+		if ret.Mode = scheme; scheme == schemeTLS {
+			ret.Mode = netTcp
+			ret.TLSPublicKeyPEM, ret.TLSPrivateKeyPEM = query.Get(keyCert), query.Get(keyKey)
+			ret.TLSPrivateKeyPassword = query.Get(keyPassword)
+			if !isTLSConfiguration(ret) {
+				err = fmt.Errorf("%w %q", Errors().AddressURL(), addr)
+				return
+			}
+		}
+		if host, port, err = net.SplitHostPort(strings.TrimSuffix(body, "/")); err != nil {
+			err = fmt.Errorf("%w %q: %s", Errors().AddressURL(), addr, err)
+			return
+		}
+		// Зона IPv6 адреса в URL экранируется: [fe80::1%25eth0].
+		if zone, e := url.PathUnescape(host); e == nil {
+			host = zone
+		}
+		err = lookupHostPort(ret, host, port)
+	}
+
+	return
+}
+
+// Возвращается истина, если в конфигурации указаны ключи TLS.
+func isTLSConfiguration(conf *Configuration) bool {
+	return conf.TLSPublicKeyPEM != "" || conf.TLSPrivateKeyPEM != ""
+}
@@ -0,0 +1,127 @@
+package net
+
+import (
+	"errors"
+	"net"
+	"testing"
+)
+
+func TestAddressScheme(t *testing.T) {
+	var tests = []struct {
+		addr   string
+		scheme string
+	}{
+		{"localhost:8080", ""},
+		{"unix:8080", netUnix},
+		{"tcp:8080", ""},
+		{"TCP4://0.0.0.0:80", netTcp4},
+		{"systemd:web", netSystemd},
+		{"tls://localhost:443", schemeTLS},
+		{"/run/x.sock", ""},
+		{":http", ""},
+	}
+
+	for n := range tests {
+		if ret := addressScheme(tests[n].addr); ret != tests[n].scheme {
+			t.Errorf("функция addressScheme(%q), вернулось: %q, ожидалось: %q", tests[n].addr, ret, tests[n].scheme)
+		}
+	}
+}
+
+func TestParseAddressURL(t *testing.T) {
+	var tests = []struct {
+		addr string
+		conf Configuration
+		err  error
+	}{
+		{"tcp4://127.0.0.1:8080", Configuration{Mode: netTcp4, Host: "127.0.0.1", Port: 8080}, nil},
+		{"tcp6://[::1]:http", Configuration{Mode: netTcp6, Host: "::1", Port: 80}, nil},
+		{"tcp6://[fe80::1%25eth0]:80", Configuration{Mode: netTcp6, Host: "fe80::1%eth0", Port: 80}, nil},
+		{"tcp://localhost:80", Configuration{Mode: netTcp, Host: "localhost", Port: 80}, nil},
+		{"udp://127.0.0.1:domain", Configuration{Mode: netUdp, Host: "127.0.0.1", Port: 53}, nil},
+		{"unix:///run/x.sock?mode=0660", Configuration{Mode: netUnix, Socket: "/run/x.sock", SocketMode: "0660"}, nil},
+		{"unix:run/x.sock", Configuration{Mode: netUnix, Socket: "run/x.sock", SocketMode: "666"}, nil},
+		{"unix:@abstract", Configuration{Mode: netUnix, Socket: "@abstract", SocketMode: "666"}, nil},
+		{"unixpacket:/run/p.sock", Configuration{Mode: netUnixPacket, Socket: "/run/p.sock", SocketMode: "666"}, nil},
+		{"systemd://web", Configuration{Mode: netSystemd, Socket: "web"}, nil},
+		{"systemd:", Configuration{Mode: netSystemd}, nil},
+		{
+			"tls://127.0.0.1:443?cert=/etc/a.crt&key=env:KEY&password=credential:pass",
+			Configuration{
+				Mode:                  netTcp,
+				Host:                  "127.0.0.1",
+				Port:                  443,
+				TLSPublicKeyPEM:       "/etc/a.crt",
+				TLSPrivateKeyPEM:      "env:KEY",
+				TLSPrivateKeyPassword: "credential:pass",
+			},
+			nil,
+		},
+		{"tls://127.0.0.1:443", Configuration{}, Errors().AddressURL()},
+		{"unix://", Configuration{}, Errors().AddressURL()},
+		{"unix:/run/x.sock?mode=999", Configuration{}, Errors().AddressURL()},
+		{"tcp://127.0.0.1", Configuration{}, Errors().AddressURL()},
+	}
+
+	for n := range tests {
+		conf, err := parseAddress(tests[n].addr, "")
+		if !errors.Is(err, tests[n].err) {
+			t.Errorf("функция parseAddress(%q), ошибка: %v, ожидалось: %v", tests[n].addr, err, tests[n].err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if tests[n].conf.SocketMode == "" {
+			tests[n].conf.SocketMode = conf.SocketMode
+		}
+		if conf.Mode != tests[n].conf.Mode || conf.Host != tests[n].conf.Host || conf.Port != tests[n].conf.Port ||
+			conf.Socket != tests[n].conf.Socket || conf.SocketMode != tests[n].conf.SocketMode ||
+			conf.TLSPublicKeyPEM != tests[n].conf.TLSPublicKeyPEM ||
+			conf.TLSPrivateKeyPEM != tests[n].conf.TLSPrivateKeyPEM ||
+			conf.TLSPrivateKeyPassword != tests[n].conf.TLSPrivateKeyPassword {
+			t.Errorf("функция parseAddress(%q), вернулось: %+v, ожидалось: %+v", tests[n].addr, *conf, tests[n].conf)
+		}
+	}
+}
+
+// Порт, заданный синонимом, определяется с учётом типа соединения.
+func TestParseAddressUdpService(t *testing.T) {
+	const service = "tftp"
+	var (
+		err  error
+		port int
+		conf *Configuration
+	)
+
+	if port, err = net.LookupPort(netUdp, service); err != nil {
+		t.Skipf("служба %q не найдена для UDP: %v", service, err)
+	}
+	if conf, err = parseAddress("127.0.0.1:"+service, netUdp); err != nil || int(conf.Port) != port {
+		t.Errorf("функция parseAddress(), порт: %d, ошибка: %v, ожидалось: %d, %v", conf.Port, err, port, nil)
+	}
+	if conf, err = parseAddress("udp://127.0.0.1:"+service, ""); err != nil || int(conf.Port) != port {
+		t.Errorf("функция parseAddress(), порт: %d, ошибка: %v, ожидалось: %d, %v", conf.Port, err, port, nil)
+	}
+}
+
+func TestListenAndServeURL(t *testing.T) {
+	var (
+		err error
+		key *tmpFile
+		crt *tmpFile
+		nut Interface
+	)
+
+	key, crt = newTmpFile(getKeyEcdsa()), newTmpFile(getCrtEcdsa())
+	defer func() { key.Clean(); crt.Clean() }()
+	nut = New().Handler(testEchoHandler)
+	if err = nut.ListenAndServe("tls://127.0.0.1:0?cert=" + crt.Filename + "&key=" + key.Filename).
+		Error(); err != nil {
+		t.Fatalf("функция ListenAndServe(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer nut.Stop()
+	if _, ok := nut.(*impl).listener.Tcp().(*tlsListener); !ok {
+		t.Errorf("функция ListenAndServe(), слушатель: %T, ожидался слушатель TLS", nut.(*impl).listener.Tcp())
+	}
+}
@@ -0,0 +1,115 @@
+package net
+
+import (
+	"net"
+	"strconv"
+)
+
+// Addr Адрес, на котором сервер фактически принимает соединения. Если порт в конфигурации не указан или равен 0,
+// возвращается порт, назначенный операционной системой. Для сокетов systemd возвращается адрес переданного сокета.
+// Если сервер слушает несколько адресов, возвращается первый из них. Если сервер не запущен, возвращается nil.
+func (nut *impl) Addr() (ret net.Addr) {
+	nut.lck.Lock()
+	defer nut.lck.Unlock()
+	if nut.listener == nil {
+		return
+	}
+	ret = nut.listener.Addr()
+
+	return
+}
+
+// BoundAddresses Все адреса, на которых сервер фактически принимает соединения, в том числе адреса из списка
+// Listen конфигурации сервера. Если сервер не запущен, возвращается nil.
+func (nut *impl) BoundAddresses() (ret []net.Addr) {
+	nut.lck.Lock()
+	defer nut.lck.Unlock()
+	if nut.listener == nil {
+		return
+	}
+	ret = boundAddresses(nut.listener)
+
+	return
+}
+
+// BoundConfiguration Копия конфигурации сервера, в которой адрес заменён на адрес, фактически назначенный
+// операционной системой или полученный от systemd. Host и Port заполняются из первого адреса, Listen заменяется
+// списком всех адресов. Исходная конфигурация сервера не изменяется. Если сервер не запущен, возвращается nil.
+func (nut *impl) BoundConfiguration() (ret *Configuration) {
+	var (
+		addrs []net.Addr
+		n     int
+	)
+
+	nut.lck.Lock()
+	defer nut.lck.Unlock()
+	if nut.listener == nil || nut.conf == nil {
+		return
+	}
+	ret, addrs = new(Configuration), boundAddresses(nut.listener)
+	*ret = *nut.conf
+	if len(addrs) == 0 {
+		return
+	}
+	// Публичный адрес пересчитывается только если он был создан из Host и Port.
+	if ret.Mode == netTcp && (ret.Address == ret.HostPort() || ret.Port == 0 && ret.Address == ret.Host) {
+		ret.Address = ""
+	}
+	boundAddressApply(ret, addrs[0])
+	if ret.Address == "" && ret.Mode == netTcp {
+		ret.Address = ret.HostPort()
+	}
+	if len(nut.conf.Listen) > 0 || len(addrs) > 1 {
+		ret.Listen = make([]string, 0, len(addrs))
+		for n = range addrs {
+			ret.Listen = append(ret.Listen, boundAddressString(addrs[n]))
+		}
+	}
+
+	return
+}
+
+// Адреса слушателя с учётом слушателей, обёрнутых в TLS и прокси-протокол, и объединённых слушателей.
+func boundAddresses(nl *netListener) (ret []net.Addr) {
+	var ltn net.Listener
+
+	if nl.isUdp() {
+		ret = []net.Addr{nl.Udp().LocalAddr()}
+		return
+	}
+	for ltn = nl.Tcp(); ltn != nil; ltn = unwrapListener(ltn) {
+		if mul, ok := ltn.(*multiListener); ok {
+			ret = mul.Addrs()
+			return
+		}
+	}
+	ret = []net.Addr{nl.Tcp().Addr()}
+
+	return
+}
+
+// Заполнение адреса конфигурации значением адреса, на котором сервер принимает соединения.
+func boundAddressApply(conf *Configuration, addr net.Addr) {
+	switch a := addr.(type) {
+	case *net.TCPAddr:
+		conf.Host, conf.Port = a.IP.String(), uint16(a.Port)
+	case *net.UDPAddr:
+		conf.Host, conf.Port = a.IP.String(), uint16(a.Port)
+	case *net.UnixAddr:
+		conf.Socket = a.Name
+	}
+}
+
+// Строковое представление адреса в формате, допустимом для списка Listen конфигурации сервера.
+func boundAddressString(addr net.Addr) (ret string) {
+	switch a := addr.(type) {
+	case *net.TCPAddr:
+		ret = net.JoinHostPort(a.IP.String(), strconv.Itoa(a.Port))
+	case *net.UnixAddr:
+		ret = a.Net + ":" + a.Name
+	default:
+		ret = addr.String()
+	}
+
+	return
+}
@@ -0,0 +1,78 @@
+package net
+
+import (
+	"net"
+	"path/filepath"
+	"testing"
+)
+
+func TestBoundAddressesEphemeralPort(t *testing.T) {
+	var (
+		err  error
+		nut  Interface
+		addr *net.TCPAddr
+		conf *Configuration
+		ok   bool
+	)
+
+	nut = New().Handler(testEchoHandler)
+	if nut.Addr() != nil || nut.BoundAddresses() != nil || nut.BoundConfiguration() != nil {
+		t.Errorf("функция Addr(), для не запущенного сервера ожидалось: %v", nil)
+	}
+	if err = nut.ListenAndServeWithConfig(&Configuration{Host: "127.0.0.1"}).Error(); err != nil {
+		t.Fatalf("функция ListenAndServeWithConfig(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer nut.Stop()
+	if addr, ok = nut.Addr().(*net.TCPAddr); !ok || addr.Port == 0 {
+		t.Fatalf("функция Addr(), вернулось: %v, ожидался адрес с портом", nut.Addr())
+	}
+	if ret := testEchoRequest(t, addr, "ping"); ret != "ping" {
+		t.Errorf("ответ сервера: %q, ожидалось: %q", ret, "ping")
+	}
+	conf = nut.BoundConfiguration()
+	if conf.Port != uint16(addr.Port) || conf.Host != "127.0.0.1" || conf.Address != addr.String() {
+		t.Errorf("функция BoundConfiguration(), вернулось: %q %d %q, ожидалось: %q %d %q",
+			conf.Host, conf.Port, conf.Address, "127.0.0.1", addr.Port, addr.String())
+	}
+	if conf.ID != nut.ID() || nut.(*impl).conf.Port != 0 {
+		t.Errorf("функция BoundConfiguration(), исходная конфигурация изменена")
+	}
+}
+
+func TestBoundAddressesMultipleTLS(t *testing.T) {
+	var (
+		err    error
+		key    *tmpFile
+		crt    *tmpFile
+		socket string
+		nut    Interface
+		addrs  []net.Addr
+		conf   *Configuration
+	)
+
+	key, crt = newTmpFile(getKeyEcdsa()), newTmpFile(getCrtEcdsa())
+	defer func() { key.Clean(); crt.Clean() }()
+	socket = filepath.Join(t.TempDir(), "bound.sock")
+	nut = New().Handler(testEchoHandler)
+	if err = nut.ListenAndServeTLSWithConfig(&Configuration{
+		Listen:           []string{"127.0.0.1:0", "unix:" + socket},
+		TLSPublicKeyPEM:  crt.Filename,
+		TLSPrivateKeyPEM: key.Filename,
+	}, nil).Error(); err != nil {
+		t.Fatalf("функция ListenAndServeTLSWithConfig(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer nut.Stop()
+	if addrs = nut.BoundAddresses(); len(addrs) != 2 {
+		t.Fatalf("функция BoundAddresses(), количество адресов: %d, ожидалось: %d", len(addrs), 2)
+	}
+	if addr, ok := addrs[0].(*net.TCPAddr); !ok || addr.Port == 0 {
+		t.Errorf("функция BoundAddresses(), вернулось: %v, ожидался адрес с портом", addrs[0])
+	}
+	if addrs[1].String() != socket {
+		t.Errorf("функция BoundAddresses(), вернулось: %q, ожидалось: %q", addrs[1], socket)
+	}
+	conf = nut.BoundConfiguration()
+	if len(conf.Listen) != 2 || conf.Listen[0] != addrs[0].String() || conf.Listen[1] != "unix:"+socket {
+		t.Errorf("функция BoundConfiguration(), Listen: %q, ожидалось: %q", conf.Listen, addrs)
+	}
+}
@@ -11,6 +11,41 @@ const (
 	cTLSIsNil                      = "Конфигурация TLS сервера пустая."
 	cServerHandlerIsNotSet         = "Не установлен обработчик основной функции TCP сервера."
 	cServerHandlerUdpIsNotSet      = "Не установлен обработчик основной функции UDP сервера."
+	cSecretNotFound                = "Секрет не найден либо пустой."
+	cCredentialsDirectory          = "Переменная окружения CREDENTIALS_DIRECTORY пустая, либо не установлена."
+	cTLSPrivateKeyPasswordRequired = "Секретный ключ зашифрован, источник пароля не указан."
+	cTLSPrivateKeyDecrypt          = "Ошибка расшифровки секретного ключа, неверный пароль либо повреждённый ключ."
+	cTLSPrivateKeyAlgorithm        = "Алгоритм шифрования секретного ключа не поддерживается."
+	cTLSSessionTicketKey           = "Ключи сессионных билетов TLS не найдены, либо имеют не верный формат."
+	cProxyUpstreamNotSet           = "Не указаны адреса вышестоящих серверов проксирования."
+	cProxyUpstreamAddress          = "Не верный адрес вышестоящего сервера проксирования."
+	cProxyBalance                  = "Не известный алгоритм балансировки нагрузки проксирования."
+	cProxyProtocolVersion          = "Не верная версия прокси-протокола, поддерживаются версии 1 и 2."
+	cProxyUpstreamUnavailable      = "Все вышестоящие серверы проксирования недоступны."
+	cListenUdpMultiple             = "Список адресов Listen не поддерживается для UDP."
+	cListenAddress                 = "Не верный адрес в списке адресов Listen."
+	cAddressURL                    = "Не верный адрес в формате URL."
+	cHostResolve                   = "Ошибка разрешения имени хоста."
+	cHostResolveNoAddress          = "Имя хоста не разрешается в адрес требуемого типа."
+	cHostResolveAmbiguous          = "Имя хоста разрешается в несколько адресов."
+	cHostResolvePolicy             = "Не известная политика разрешения имени хоста."
+	cBindToDevice                  = "Для привязки сокета к сетевому интерфейсу в Host должно быть указано имя сетевого интерфейса."
+	cBindToDeviceNotSupported      = "Привязка сокета к сетевому интерфейсу не поддерживается операционной системой."
+	cSocketOption                  = "Ошибка установки опции сокета."
+	cSocketOptionNotSupported      = "Опция сокета не поддерживается операционной системой."
+	cListenShards                  = "Отдельные слушатели создаются только для одного TCP/IP адреса с включённым ReusePort."
+	cTCPInfoConn                   = "Соединение не является TCP соединением."
+	cOriginalDestination           = "Исходный адрес назначения не определён."
+	cSocketOwner                   = "Не верный владелец юникс-сокета."
+	cSocketGroup                   = "Не верная группа юникс-сокета."
+	cSocketPermission              = "Ошибка установки прав доступа к юникс-сокету."
+	cSocketDirectory               = "Ошибка создания директории юникс-сокета."
+	cSocketInUse                   = "Юникс-сокет используется другим процессом."
+	cSocketNotSocket               = "Путь юникс-сокета занят файлом, который не является сокетом."
+	cAbstractSocketNotSupported    = "Юникс-сокет в абстрактном пространстве имён поддерживается только в Linux."
+	cPeerCredentialsConn           = "Соединение не является соединением юникс-сокета, учётные данные процесса клиента недоступны."
+	cSocketAccess                  = "Ошибка доступа к пути юникс-сокета или файлу блокировки."
+	cDevSelfSignedCA               = "Не корректный сохранённый центр сертификации режима разработки."
 )
 
 // Константы указываются в объектах в качестве фиксированного адреса на протяжении всего времени работы приложения.
@@ -26,6 +61,41 @@ var (
 	errTLSIsNil                      = err(cTLSIsNil)
 	errServerHandlerIsNotSet         = err(cServerHandlerIsNotSet)
 	errServerHandlerUdpIsNotSet      = err(cServerHandlerUdpIsNotSet)
+	errSecretNotFound                = err(cSecretNotFound)
+	errCredentialsDirectory          = err(cCredentialsDirectory)
+	errTLSPrivateKeyPasswordRequired = err(cTLSPrivateKeyPasswordRequired)
+	errTLSPrivateKeyDecrypt          = err(cTLSPrivateKeyDecrypt)
+	errTLSPrivateKeyAlgorithm        = err(cTLSPrivateKeyAlgorithm)
+	errTLSSessionTicketKey           = err(cTLSSessionTicketKey)
+	errProxyUpstreamNotSet           = err(cProxyUpstreamNotSet)
+	errProxyUpstreamAddress          = err(cProxyUpstreamAddress)
+	errProxyBalance                  = err(cProxyBalance)
+	errProxyProtocolVersion          = err(cProxyProtocolVersion)
+	errProxyUpstreamUnavailable      = err(cProxyUpstreamUnavailable)
+	errListenUdpMultiple             = err(cListenUdpMultiple)
+	errListenAddress                 = err(cListenAddress)
+	errAddressURL                    = err(cAddressURL)
+	errHostResolve                   = err(cHostResolve)
+	errHostResolveNoAddress          = err(cHostResolveNoAddress)
+	errHostResolveAmbiguous          = err(cHostResolveAmbiguous)
+	errHostResolvePolicy             = err(cHostResolvePolicy)
+	errBindToDevice                  = err(cBindToDevice)
+	errBindToDeviceNotSupported      = err(cBindToDeviceNotSupported)
+	errSocketOption                  = err(cSocketOption)
+	errSocketOptionNotSupported      = err(cSocketOptionNotSupported)
+	errListenShards                  = err(cListenShards)
+	errTCPInfoConn                   = err(cTCPInfoConn)
+	errOriginalDestination           = err(cOriginalDestination)
+	errSocketOwner                   = err(cSocketOwner)
+	errSocketGroup                   = err(cSocketGroup)
+	errSocketPermission              = err(cSocketPermission)
+	errSocketDirectory               = err(cSocketDirectory)
+	errSocketInUse                   = err(cSocketInUse)
+	errSocketNotSocket               = err(cSocketNotSocket)
+	errAbstractSocketNotSupported    = err(cAbstractSocketNotSupported)
+	errPeerCredentialsConn           = err(cPeerCredentialsConn)
+	errSocketAccess                  = err(cSocketAccess)
+	errDevSelfSignedCA               = err(cDevSelfSignedCA)
 )
 
 type (
@@ -68,3 +138,108 @@ func (e *Error) ServerHandlerIsNotSet() error { return &errServerHandlerIsNotSet
 
 // ServerHandlerUdpIsNotSet Не установлен обработчик основной функции UDP сервера.
 func (e *Error) ServerHandlerUdpIsNotSet() error { return &errServerHandlerUdpIsNotSet }
+
+// SecretNotFound Секрет не найден либо пустой.
+func (e *Error) SecretNotFound() error { return &errSecretNotFound }
+
+// CredentialsDirectory Переменная окружения CREDENTIALS_DIRECTORY пустая, либо не установлена.
+func (e *Error) CredentialsDirectory() error { return &errCredentialsDirectory }
+
+// TLSPrivateKeyPasswordRequired Секретный ключ зашифрован, источник пароля не указан.
+func (e *Error) TLSPrivateKeyPasswordRequired() error { return &errTLSPrivateKeyPasswordRequired }
+
+// TLSPrivateKeyDecrypt Ошибка расшифровки секретного ключа, неверный пароль либо повреждённый ключ.
+func (e *Error) TLSPrivateKeyDecrypt() error { return &errTLSPrivateKeyDecrypt }
+
+// TLSPrivateKeyAlgorithm Алгоритм шифрования секретного ключа не поддерживается.
+func (e *Error) TLSPrivateKeyAlgorithm() error { return &errTLSPrivateKeyAlgorithm }
+
+// TLSSessionTicketKey Ключи сессионных билетов TLS не найдены, либо имеют не верный формат.
+func (e *Error) TLSSessionTicketKey() error { return &errTLSSessionTicketKey }
+
+// ProxyUpstreamNotSet Не указаны адреса вышестоящих серверов проксирования.
+func (e *Error) ProxyUpstreamNotSet() error { return &errProxyUpstreamNotSet }
+
+// ProxyUpstreamAddress Не верный адрес вышестоящего сервера проксирования.
+func (e *Error) ProxyUpstreamAddress() error { return &errProxyUpstreamAddress }
+
+// ProxyBalance Не известный алгоритм балансировки нагрузки проксирования.
+func (e *Error) ProxyBalance() error { return &errProxyBalance }
+
+// ProxyProtocolVersion Не верная версия прокси-протокола, поддерживаются версии 1 и 2.
+func (e *Error) ProxyProtocolVersion() error { return &errProxyProtocolVersion }
+
+// ProxyUpstreamUnavailable Все вышестоящие серверы проксирования недоступны.
+func (e *Error) ProxyUpstreamUnavailable() error { return &errProxyUpstreamUnavailable }
+
+// ListenUdpMultiple Список адресов Listen не поддерживается для UDP.
+func (e *Error) ListenUdpMultiple() error { return &errListenUdpMultiple }
+
+// ListenAddress Не верный адрес в списке адресов Listen.
+func (e *Error) ListenAddress() error { return &errListenAddress }
+
+// AddressURL Не верный адрес в формате URL.
+func (e *Error) AddressURL() error { return &errAddressURL }
+
+// HostResolve Ошибка разрешения имени хоста.
+func (e *Error) HostResolve() error { return &errHostResolve }
+
+// HostResolveNoAddress Имя хоста не разрешается в адрес требуемого типа.
+func (e *Error) HostResolveNoAddress() error { return &errHostResolveNoAddress }
+
+// HostResolveAmbiguous Имя хоста разрешается в несколько адресов.
+func (e *Error) HostResolveAmbiguous() error { return &errHostResolveAmbiguous }
+
+// HostResolvePolicy Не известная политика разрешения имени хоста.
+func (e *Error) HostResolvePolicy() error { return &errHostResolvePolicy }
+
+// BindToDevice Для привязки сокета к сетевому интерфейсу в Host должно быть указано имя сетевого интерфейса.
+func (e *Error) BindToDevice() error { return &errBindToDevice }
+
+// BindToDeviceNotSupported Привязка сокета к сетевому интерфейсу не поддерживается операционной системой.
+func (e *Error) BindToDeviceNotSupported() error { return &errBindToDeviceNotSupported }
+
+// SocketOption Ошибка установки опции сокета.
+func (e *Error) SocketOption() error { return &errSocketOption }
+
+// SocketOptionNotSupported Опция сокета не поддерживается операционной системой.
+func (e *Error) SocketOptionNotSupported() error { return &errSocketOptionNotSupported }
+
+// ListenShards Отдельные слушатели создаются только для одного TCP/IP адреса с включённым ReusePort.
+func (e *Error) ListenShards() error { return &errListenShards }
+
+// TCPInfoConn Соединение не является TCP соединением.
+func (e *Error) TCPInfoConn() error { return &errTCPInfoConn }
+
+// OriginalDestination Исходный адрес назначения не определён.
+func (e *Error) OriginalDestination() error { return &errOriginalDestination }
+
+// SocketOwner Не верный владелец юникс-сокета.
+func (e *Error) SocketOwner() error { return &errSocketOwner }
+
+// SocketGroup Не верная группа юникс-сокета.
+func (e *Error) SocketGroup() error { return &errSocketGroup }
+
+// SocketPermission Ошибка установки прав доступа к юникс-сокету.
+func (e *Error) SocketPermission() error { return &errSocketPermission }
+
+// SocketDirectory Ошибка создания директории юникс-сокета.
+func (e *Error) SocketDirectory() error { return &errSocketDirectory }
+
+// SocketInUse Юникс-сокет используется другим процессом.
+func (e *Error) SocketInUse() error { return &errSocketInUse }
+
+// SocketNotSocket Путь юникс-сокета занят файлом, который не является сокетом.
+func (e *Error) SocketNotSocket() error { return &errSocketNotSocket }
+
+// AbstractSocketNotSupported Юникс-сокет в абстрактном пространстве имён поддерживается только в Linux.
+func (e *Error) AbstractSocketNotSupported() error { return &errAbstractSocketNotSupported }
+
+// PeerCredentialsConn Соединение не является соединением юникс-сокета, учётные данные процесса клиента недоступны.
+func (e *Error) PeerCredentialsConn() error { return &errPeerCredentialsConn }
+
+// SocketAccess Ошибка доступа к пути юникс-сокета или файлу блокировки.
+func (e *Error) SocketAccess() error { return &errSocketAccess }
+
+// DevSelfSignedCA Не корректный сохранённый центр сертификации режима разработки.
+func (e *Error) DevSelfSignedCA() error { return &errDevSelfSignedCA }
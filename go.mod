@@ -1,9 +1,15 @@
 module github.com/webnice/net
 
-go 1.19
+go 1.21
 
 require github.com/go-chi/chi/v5 v5.0.10
 
 require github.com/google/uuid v1.6.0
 
 require github.com/pires/go-proxyproto v0.8.0
+
+require golang.org/x/crypto v0.11.0
+
+require golang.org/x/sys v0.10.0
+
+require software.sslmate.com/src/go-pkcs12 v0.5.0
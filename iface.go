@@ -0,0 +1,34 @@
+package net
+
+import "net"
+
+// Текущие IP адреса сетевого интерфейса. Для локальных IPv6 адресов канала (fe80::/10) указывается зона,
+// равная имени интерфейса.
+func interfaceAddrs(iface *net.Interface) (ret []net.IPAddr, err error) {
+	var (
+		addrs []net.Addr
+		ip    net.IP
+		zone  string
+		n     int
+	)
+
+	if addrs, err = iface.Addrs(); err != nil {
+		return
+	}
+	for n = range addrs {
+		switch a := addrs[n].(type) {
+		case *net.IPNet:
+			ip = a.IP
+		case *net.IPAddr:
+			ip = a.IP
+		default:
+			continue
+		}
+		if zone = ""; ip.To4() == nil && ip.IsLinkLocalUnicast() {
+			zone = iface.Name
+		}
+		ret = append(ret, net.IPAddr{IP: ip, Zone: zone})
+	}
+
+	return
+}
@@ -0,0 +1,20 @@
+//go:build linux
+
+package net
+
+import "syscall"
+
+// Функция настройки сокета, привязывающая сокет к сетевому интерфейсу опцией SO_BINDTODEVICE.
+func bindToDeviceControl(device string) (ret socketControlFn, err error) {
+	ret = func(network string, address string, c syscall.RawConn) (err error) {
+		if e := c.Control(func(fd uintptr) {
+			err = syscall.SetsockoptString(int(fd), syscall.SOL_SOCKET, syscall.SO_BINDTODEVICE, device)
+		}); e != nil {
+			err = e
+		}
+
+		return
+	}
+
+	return
+}
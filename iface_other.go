@@ -0,0 +1,9 @@
+//go:build !linux
+
+package net
+
+// Привязка сокета к сетевому интерфейсу опцией SO_BINDTODEVICE поддерживается только в Linux.
+func bindToDeviceControl(_ string) (ret socketControlFn, err error) {
+	err = Errors().BindToDeviceNotSupported()
+	return
+}
@@ -0,0 +1,91 @@
+package net
+
+import (
+	"errors"
+	"net"
+	"testing"
+)
+
+// Имя локального сетевого интерфейса (loopback) и его адреса.
+func testLoopbackInterface(t *testing.T) (ret *net.Interface) {
+	var (
+		err    error
+		ifaces []net.Interface
+		n      int
+	)
+
+	if ifaces, err = net.Interfaces(); err != nil {
+		t.Skipf("функция Interfaces(), ошибка: %v", err)
+	}
+	for n = range ifaces {
+		if ifaces[n].Flags&net.FlagLoopback != 0 && ifaces[n].Flags&net.FlagUp != 0 {
+			ret = &ifaces[n]
+			return
+		}
+	}
+	t.Skip("локальный сетевой интерфейс не найден")
+
+	return
+}
+
+func TestResolveHostInterface(t *testing.T) {
+	var (
+		err   error
+		iface *net.Interface
+		hosts []string
+	)
+
+	iface = testLoopbackInterface(t)
+	if hosts, err = resolveHost(&Configuration{Host: iface.Name, HostResolve: HostResolveIPv4}); err != nil {
+		t.Fatalf("функция resolveHost(%q), ошибка: %v, ожидалось: %v", iface.Name, err, nil)
+	}
+	if len(hosts) != 1 || net.ParseIP(hosts[0]).To4() == nil {
+		t.Errorf("функция resolveHost(%q), вернулось: %q, ожидался IPv4 адрес", iface.Name, hosts)
+	}
+	if hosts, err = resolveHost(&Configuration{Host: iface.Name, Mode: netTcp4, HostResolve: HostResolveIPv6}); !errors.Is(
+		err, Errors().HostResolveNoAddress(),
+	) {
+		t.Errorf("функция resolveHost(%q), вернулось: %q, ошибка: %v, ожидалось: %v",
+			iface.Name, hosts, err, Errors().HostResolveNoAddress())
+	}
+}
+
+func TestListenInterface(t *testing.T) {
+	var (
+		err   error
+		iface *net.Interface
+		nut   Interface
+		addr  *net.TCPAddr
+		ok    bool
+	)
+
+	iface = testLoopbackInterface(t)
+	nut = New().Handler(testEchoHandler)
+	if err = nut.ListenAndServeWithConfig(&Configuration{
+		Host:         iface.Name,
+		HostResolve:  HostResolveIPv4,
+		BindToDevice: true,
+	}).Error(); err != nil {
+		t.Skipf("функция ListenAndServeWithConfig(), ошибка: %v", err)
+	}
+	defer nut.Stop()
+	if addr, ok = nut.Addr().(*net.TCPAddr); !ok || !addr.IP.IsLoopback() {
+		t.Fatalf("функция Addr(), вернулось: %v, ожидался адрес интерфейса %q", nut.Addr(), iface.Name)
+	}
+	if ret := testEchoRequest(t, addr, "ping"); ret != "ping" {
+		t.Errorf("ответ сервера: %q, ожидалось: %q", ret, "ping")
+	}
+}
+
+func TestListenConfigBindToDevice(t *testing.T) {
+	var err error
+
+	if _, err = listenConfig(&Configuration{Host: "127.0.0.1", BindToDevice: true}); !errors.Is(
+		err, Errors().BindToDevice(),
+	) {
+		t.Errorf("функция listenConfig(), ошибка: %v, ожидалось: %v", err, Errors().BindToDevice())
+	}
+	if _, err = listenConfig(&Configuration{Host: "127.0.0.1"}); err != nil {
+		t.Errorf("функция listenConfig(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"crypto/tls"
 	"fmt"
 	"net"
-	"os"
 	"path"
 
 	"github.com/google/uuid"
@@ -13,12 +12,18 @@ import (
 
 // ListenAndServe Открытие адреса или сокета без использования конфигурации сервера (конфигурация по
 // умолчанию), после успешного открытия адреса, выполняется запуск сервера для обслуживания входящих соединений.
+// Адрес может быть указан в формате URL: tcp4://, tcp6://, udp://host:port, unix:///путь?mode=0660, unix:@имя,
+// systemd://имя, tls://host:port?cert=...&key=...
 func (nut *impl) ListenAndServe(addr string) Interface {
 	var conf *Configuration
 
 	if conf, nut.err = parseAddress(addr, ""); nut.err != nil {
 		return nut
 	}
+	// Адрес в формате tls://host:port?cert=...&key=...
+	if isTLSConfiguration(conf) {
+		return nut.ListenAndServeTLSWithConfig(conf, nil)
+	}
 
 	return nut.ListenAndServeWithConfig(conf)
 }
@@ -32,7 +37,9 @@ func (nut *impl) ListenAndServeTLS(addr string, certFile string, keyFile string,
 	if conf, nut.err = parseAddress(addr, ""); nut.err != nil {
 		return nut
 	}
-	conf.TLSPublicKeyPEM, conf.TLSPrivateKeyPEM = certFile, keyFile
+	if certFile != "" || keyFile != "" {
+		conf.TLSPublicKeyPEM, conf.TLSPrivateKeyPEM = certFile, keyFile
+	}
 
 	return nut.ListenAndServeTLSWithConfig(conf, tlsConfig)
 }
@@ -60,7 +67,7 @@ func (nut *impl) ListenAndServeTLSWithConfig(conf *Configuration, tlsConfig *tls
 	}
 	nut.conf = conf
 	if tlsConfig == nil {
-		if tlsConfig, nut.err = nut.NewTLSConfigDefault(conf.TLSPublicKeyPEM, conf.TLSPrivateKeyPEM); nut.err != nil {
+		if tlsConfig, nut.err = nut.NewTLSConfig(conf); nut.err != nil {
 			return nut
 		}
 	}
@@ -81,6 +88,17 @@ func (nut *impl) NewListener(conf *Configuration) (
 	)
 
 	defaultConfiguration(conf)
+	if !peerCredentialsSupported && (len(conf.SocketPeerUID) > 0 || len(conf.SocketPeerGID) > 0) {
+		err = fmt.Errorf("%w SocketPeerUID, SocketPeerGID", Errors().SocketOptionNotSupported())
+		return
+	}
+	if len(conf.Listen) > 0 {
+		if ret, err = nut.newListenerMulti(conf); err != nil {
+			return
+		}
+		ret = proxyProtocolListener(conf, peerCredentialsListener(conf, ret))
+		return
+	}
 	switch conf.Mode {
 	case netSystemd:
 		switch conf.Socket {
@@ -103,29 +121,52 @@ func (nut *impl) NewListener(conf *Configuration) (
 		if len(listeners) > 0 {
 			ret = listeners[0]
 		}
+		// Статистика собирается только для TCP сокетов, переданных systemd.
+		if _, ok = ret.(*net.TCPListener); ok {
+			ret = nut.tcpInfoListener(conf, ret)
+		}
 	case netUnix, netUnixPacket:
-		_ = os.Remove(conf.Socket)
-		ret, err = net.Listen(conf.Mode, conf.Socket)
-		_ = os.Chmod(conf.Socket, parseFileModeWithDefault(conf.SocketMode))
-	case netUdp, netUdp4, netUdp6, netUnixgram:
+		ret, err = listenUnix(conf)
+	case netUnixgram:
 		rpc, err = net.ListenPacket(conf.Mode, conf.HostPort())
+	case netUdp, netUdp4, netUdp6:
+		rpc, err = listenPacketResolved(conf)
 	default:
-		ret, err = net.Listen(conf.Mode, conf.HostPort())
-	}
-	// Включение ProxyProtocol.
-	if ret != nil && conf.ProxyProtocol {
-		//
-		// TODO: Необходимо реализовать функции ConnPolicy и ValidateHeader.
-		// TODO: ВНИМАНИЕ!!! Архитектора функции ConnPolicy придумана плохо, "одарённым" автором, возможна паника!
-		// TODO: ВНИМАНИЕ!!! Архитектора функции ValidateHeader придумана плохо, "одарённым" автором, возможна паника!
-		//
-		ret = &proxyproto.Listener{
-			Listener:          ret,
-			ReadHeaderTimeout: conf.ProxyProtocolReadHeaderTimeout,
-			ConnPolicy:        nil,
-			ValidateHeader:    nil,
+		if ret, err = listenResolved(conf); err == nil {
+			ret = nut.tcpInfoListener(conf, ret)
 		}
 	}
+	ret = proxyProtocolListener(conf, peerCredentialsListener(conf, ret))
+
+	return
+}
+
+// Включение сбора статистики TCP соединений при закрытии, если TCPInfoOnClose включён в конфигурации сервера.
+func (nut *impl) tcpInfoListener(conf *Configuration, ltn net.Listener) (ret net.Listener) {
+	if ret = ltn; ret == nil || !conf.TCPInfoOnClose {
+		return
+	}
+	ret = newTCPInfoListener(ret, nut.tcpInfo)
+
+	return
+}
+
+// Включение ProxyProtocol для слушателя, если прокси-протокол включён в конфигурации сервера.
+func proxyProtocolListener(conf *Configuration, ltn net.Listener) (ret net.Listener) {
+	if ret = ltn; ret == nil || !conf.ProxyProtocol {
+		return
+	}
+	//
+	// TODO: Необходимо реализовать функции ConnPolicy и ValidateHeader.
+	// TODO: ВНИМАНИЕ!!! Архитектора функции ConnPolicy придумана плохо, "одарённым" автором, возможна паника!
+	// TODO: ВНИМАНИЕ!!! Архитектора функции ValidateHeader придумана плохо, "одарённым" автором, возможна паника!
+	//
+	ret = &proxyproto.Listener{
+		Listener:          ret,
+		ReadHeaderTimeout: conf.ProxyProtocolReadHeaderTimeout,
+		ConnPolicy:        nil,
+		ValidateHeader:    nil,
+	}
 
 	return
 }
@@ -140,41 +181,70 @@ func (nut *impl) NewListenerTLS(conf *Configuration, tlsConfig *tls.Config) (
 	const errTemplate = "публичный ключ %q, секретный ключ %q, ошибка: %s"
 	var (
 		lst net.Listener
+		tln *tlsListener
 		ler error
 	)
 
 	if lst, rpc, ler = nut.NewListener(conf); tlsConfig == nil {
-		if tlsConfig, err = nut.NewTLSConfigDefault(conf.TLSPublicKeyPEM, conf.TLSPrivateKeyPEM); err != nil {
-			err = fmt.Errorf(errTemplate, conf.TLSPublicKeyPEM, conf.TLSPrivateKeyPEM, err)
+		if tlsConfig, err = nut.NewTLSConfig(conf); err != nil {
+			err = fmt.Errorf(errTemplate, sourceName(conf.TLSPublicKeyPEM), sourceName(conf.TLSPrivateKeyPEM), err)
+			if lst != nil {
+				_ = lst.Close()
+			}
 			return
 		}
 	}
-	if err = ler; ler != nil {
+	if err = ler; ler != nil || lst == nil {
+		return
+	}
+	// Ключи сессионных билетов и запись секретов применяются к копии TLS конфигурации, переданная конфигурация
+	// может использоваться совместно с другими слушателями и не изменяется.
+	tlsConfig = tlsConfig.Clone()
+	switch conf.TLSHandshakeEager {
+	case true:
+		tln = newTLSListener(newHandshakeListener(
+			lst, tlsConfig, conf.TLSHandshakeTimeout, conf.TLSHandshakeConcurrency, nut.tlsFailures,
+		), lst)
+	default:
+		tln = newTLSListener(tls.NewListener(lst, tlsConfig), lst)
+	}
+	if err = nut.tlsListenerApply(conf, tlsConfig, tln); err != nil {
+		_ = tln.Close()
 		return
 	}
-	ret = tls.NewListener(lst, tlsConfig)
+	ret = tln
 
 	return
 }
 
 // NewTLSConfigDefault Создание TLS конфигурации по умолчанию, на основе секретного и публичного ключей.
+// Вместо пути к файлу можно указать PEM содержимое, ссылку на переменную окружения "env:ИМЯ" или ссылку на
+// учётные данные systemd "credential:ИМЯ".
 func (nut *impl) NewTLSConfigDefault(tlsPublicFile string, tlsPrivateFile string) (ret *tls.Config, err error) {
-	ret = &tls.Config{
+	ret = tlsConfigDefault()
+	ret.Certificates = make([]tls.Certificate, 1)
+	if ret.Certificates[0], err = loadX509KeyPair(tlsPublicFile, tlsPrivateFile, ""); err != nil {
+		return
+	}
+
+	return
+}
+
+// Создание TLS конфигурации по умолчанию без сертификатов.
+// Наборы шифров TLS 1.2 поддерживают сертификаты с ключами RSA и ECDSA.
+func tlsConfigDefault() *tls.Config {
+	return &tls.Config{
 		MinVersion:       tls.VersionTLS12,
 		CurvePreferences: []tls.CurveID{tls.CurveP521, tls.CurveP384, tls.CurveP256},
 		CipherSuites: []uint16{
+			tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+			tls.TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA,
 			tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
 			tls.TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA,
 			tls.TLS_RSA_WITH_AES_256_GCM_SHA384,
 			tls.TLS_RSA_WITH_AES_256_CBC_SHA,
 		},
-		Certificates: make([]tls.Certificate, 1),
 	}
-	if ret.Certificates[0], err = tls.LoadX509KeyPair(tlsPublicFile, tlsPrivateFile); err != nil {
-		return
-	}
-
-	return
 }
 
 // Listen Создание слушателя по TLS конфигурации, запуск прослушивания входящих соединений и запуск сервера.
@@ -197,6 +267,13 @@ func (nut *impl) Listen(tlsConfig *tls.Config) Interface {
 	if nut.err != nil {
 		return nut
 	}
+	// Переключение соединений в режим TLS (STARTTLS) использует TLS конфигурацию слушателя сервера.
+	if tln, ok := lTcp.(*tlsListener); ok {
+		nut.lck.Lock()
+		nut.startTLSReset()
+		nut.tlsStart = tln
+		nut.lck.Unlock()
+	}
 	switch {
 	case lUdp != nil:
 		return nut.ServeUdp(lUdp)
@@ -260,14 +337,15 @@ func (nut *impl) serve(nl *netListener, id string) Interface {
 func (nut *impl) run(onUp chan struct{}) {
 	var err error
 
-	// Финализация сокетов.
+	// Финализация сокетов. Слушатель юникс-сокета при закрытии удаляет файл сокета, только если путь всё ещё
+	// указывает на сокет слушателя, а не на сокет, открытый другим процессом.
 	defer func() {
 		if nut.conf.Socket == "" {
 			return
 		}
 		switch nut.conf.Mode {
 		case netUnix, netUnixPacket:
-			_ = os.Remove(nut.conf.Socket)
+			_ = nut.listener.Close()
 		}
 	}()
 	// Проверка и создание уникального ID сервера.
@@ -0,0 +1,247 @@
+package net
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"path"
+	"strings"
+	"sync"
+)
+
+// Создание слушателя для всех адресов списка Listen конфигурации сервера.
+// Адреса открываются атомарно, при ошибке открытия любого адреса уже открытые адреса закрываются.
+func (nut *impl) newListenerMulti(conf *Configuration) (ret net.Listener, err error) {
+	var (
+		listeners []net.Listener
+		systemd   map[string][]net.Listener
+		sub       *Configuration
+		ltn       net.Listener
+		name      string
+		n         int
+	)
+
+	switch conf.Mode {
+	case netUdp, netUdp4, netUdp6, netUnixgram:
+		err = Errors().ListenUdpMultiple()
+		return
+	}
+	defer func() {
+		// Сокеты systemd, не указанные в списке адресов, не используются.
+		for name = range systemd {
+			closeListeners(systemd[name])
+		}
+		if err != nil {
+			closeListeners(listeners)
+		}
+	}()
+	for n = range conf.Listen {
+		if sub, err = listenAddressConfiguration(conf, conf.Listen[n]); err != nil {
+			return
+		}
+		switch sub.Mode {
+		case netSystemd:
+			if systemd == nil {
+				if systemd, err = nut.ListenersSystemdWithNames(); err != nil {
+					return
+				}
+			}
+			if name = path.Base(sub.Socket); len(systemd[name]) == 0 {
+				err = fmt.Errorf("%w %q", Errors().ListenSystemdNotFound(), name)
+				return
+			}
+			listeners = append(listeners, systemd[name]...)
+			delete(systemd, name)
+		default:
+			if ltn, _, err = nut.NewListener(sub); err != nil {
+				err = fmt.Errorf("адрес %q, ошибка: %w", conf.Listen[n], err)
+				return
+			}
+			listeners = append(listeners, ltn)
+		}
+	}
+	switch len(listeners) {
+	case 1:
+		ret = listeners[0]
+	default:
+		ret = newMultiListener(listeners)
+	}
+
+	return
+}
+
+// Создание конфигурации сервера для одного адреса из списка Listen.
+// Адрес может быть указан в формате URL, так же как для ListenAndServe, кроме адресов UDP.
+func listenAddressConfiguration(conf *Configuration, addr string) (ret *Configuration, err error) {
+	var (
+		host *Configuration
+		mode string
+	)
+
+	ret = new(Configuration)
+	*ret = *conf
+	ret.Listen, ret.ProxyProtocol, ret.Address, ret.Socket = nil, false, "", ""
+	// Проверка учётных данных клиентов выполняется один раз слушателем, объединяющим соединения.
+	ret.SocketPeerUID, ret.SocketPeerGID = nil, nil
+	switch addr = strings.TrimSpace(addr); {
+	case addressScheme(addr) == schemeTLS:
+		err = fmt.Errorf("%w %q", Errors().ListenAddress(), addr)
+		return
+	case addressScheme(addr) != "":
+		if host, err = addressURLConfiguration(addr); err != nil {
+			return
+		}
+		ret.Mode, ret.Host, ret.Port, ret.Socket = host.Mode, host.Host, host.Port, host.Socket
+		if host.SocketMode != "" {
+			ret.SocketMode = host.SocketMode
+		}
+	case strings.HasPrefix(addr, "/"):
+		if ret.Mode, ret.Socket = netUnix, addr; conf.Mode == netUnixPacket {
+			ret.Mode = netUnixPacket
+		}
+	default:
+		switch mode = conf.Mode; mode {
+		case netTcp, netTcp4, netTcp6:
+		default:
+			mode = netTcp
+		}
+		if _, _, err = net.SplitHostPort(addr); err != nil {
+			err = fmt.Errorf("%w %q", Errors().ListenAddress(), addr)
+			return
+		}
+		if host, err = parseAddress(addr, mode); err != nil {
+			err = fmt.Errorf("%w %q: %s", Errors().ListenAddress(), addr, err)
+			return
+		}
+		ret.Mode, ret.Host, ret.Port = mode, host.Host, host.Port
+	}
+	if ret.Mode == netTcp {
+		ret.Mode = wildcardHostMode(ret.Host, ret.Mode)
+	}
+	switch ret.Mode {
+	case netTcp, netTcp4, netTcp6:
+	case netUnix, netUnixPacket, netSystemd:
+		if ret.Socket != "" {
+			break
+		}
+		fallthrough
+	default:
+		err = fmt.Errorf("%w %q", Errors().ListenAddress(), addr)
+	}
+
+	return
+}
+
+// Режим TCP/IP для адреса, заданного IP адресом всех интерфейсов. Режим tcp для адреса "0.0.0.0" открывает
+// двухстековый IPv6 сокет, который занимает порт и для IPv4, и для IPv6, поэтому адреса "0.0.0.0:порт" и
+// "[::]:порт" одного списка не могут быть открыты одновременно. Адрес "0.0.0.0" открывается в режиме tcp4, адрес
+// "::" открывается в режиме tcp6, сокет которого принимает только IPv6 соединения (IPV6_V6ONLY).
+func wildcardHostMode(host string, mode string) (ret string) {
+	var ip net.IP
+
+	switch ret, ip = mode, net.ParseIP(strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")); {
+	case ip == nil || !ip.IsUnspecified():
+	case ip.To4() != nil:
+		ret = netTcp4
+	default:
+		ret = netTcp6
+	}
+
+	return
+}
+
+// Закрытие всех слушателей.
+func closeListeners(listeners []net.Listener) {
+	var n int
+
+	for n = range listeners {
+		_ = listeners[n].Close()
+	}
+}
+
+// Конструктор слушателя, объединяющего соединения нескольких слушателей.
+func newMultiListener(listeners []net.Listener) (ret *multiListener) {
+	var n int
+
+	ret = &multiListener{
+		listeners: listeners,
+		conns:     make(chan net.Conn),
+		errs:      make(chan error),
+		done:      make(chan struct{}),
+		once:      new(sync.Once),
+	}
+	for n = range listeners {
+		go ret.acceptLoop(listeners[n])
+	}
+
+	return
+}
+
+// Accept Ожидание соединения любого из объединяемых слушателей.
+func (mul *multiListener) Accept() (ret net.Conn, err error) {
+	select {
+	case ret = <-mul.conns:
+	case err = <-mul.errs:
+	case <-mul.done:
+		err = net.ErrClosed
+	}
+
+	return
+}
+
+// Close Закрытие всех объединяемых слушателей.
+func (mul *multiListener) Close() (err error) {
+	var n int
+
+	mul.once.Do(func() { close(mul.done) })
+	for n = range mul.listeners {
+		if e := mul.listeners[n].Close(); e != nil && err == nil && !errors.Is(e, net.ErrClosed) {
+			err = e
+		}
+	}
+
+	return
+}
+
+// Addr Адрес первого из объединяемых слушателей.
+func (mul *multiListener) Addr() net.Addr { return mul.listeners[0].Addr() }
+
+// Addrs Адреса всех объединяемых слушателей.
+func (mul *multiListener) Addrs() (ret []net.Addr) {
+	var n int
+
+	ret = make([]net.Addr, 0, len(mul.listeners))
+	for n = range mul.listeners {
+		ret = append(ret, mul.listeners[n].Addr())
+	}
+
+	return
+}
+
+// Приём соединений одного из объединяемых слушателей.
+func (mul *multiListener) acceptLoop(ltn net.Listener) {
+	var (
+		conn net.Conn
+		err  error
+	)
+
+	for {
+		if conn, err = ltn.Accept(); err != nil {
+			select {
+			case mul.errs <- err:
+			case <-mul.done:
+				return
+			}
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			continue
+		}
+		select {
+		case mul.conns <- conn:
+		case <-mul.done:
+			_ = conn.Close()
+			return
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package net
+
+import (
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Основная функция TCP сервера для тестирования, возвращающая полученные данные.
+func testEchoHandler(ltn net.Listener) (err error) {
+	var conn net.Conn
+
+	for {
+		if conn, err = ltn.Accept(); err != nil {
+			return
+		}
+		go func(c net.Conn) {
+			defer func() { _ = c.Close() }()
+			_, _ = io.Copy(c, c)
+		}(conn)
+	}
+}
+
+// Отправка данных серверу, возвращающему полученные данные, и получение ответа.
+func testEchoRequest(t *testing.T, addr net.Addr, data string) (ret string) {
+	var (
+		err  error
+		conn net.Conn
+		buf  []byte
+	)
+
+	if conn, err = net.Dial(addr.Network(), addr.String()); err != nil {
+		t.Fatalf("функция Dial(%q), ошибка: %v, ожидалось: %v", addr, err, nil)
+	}
+	defer func() { _ = conn.Close() }()
+	if _, err = conn.Write([]byte(data)); err != nil {
+		t.Fatalf("функция Write(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	_ = closeWrite(conn)
+	if buf, err = io.ReadAll(conn); err != nil {
+		t.Fatalf("функция ReadAll(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	ret = string(buf)
+
+	return
+}
+
+func TestListenAddressConfiguration(t *testing.T) {
+	var tests = []struct {
+		addr   string
+		mode   string
+		host   string
+		port   uint16
+		socket string
+		err    error
+	}{
+		{"127.0.0.1:8080", netTcp, "127.0.0.1", 8080, "", nil},
+		{"[::1]:http", netTcp, "::1", 80, "", nil},
+		{"unix:/run/a.sock", netUnix, "", 0, "/run/a.sock", nil},
+		{"/run/b.sock", netUnix, "", 0, "/run/b.sock", nil},
+		{"systemd:web", netSystemd, "", 0, "web", nil},
+		{"127.0.0.1", "", "", 0, "", Errors().ListenAddress()},
+		{"unix:", "", "", 0, "", Errors().AddressURL()},
+		{"udp://127.0.0.1:53", "", "", 0, "", Errors().ListenAddress()},
+		{"tcp6://[::1]:8080", netTcp6, "::1", 8080, "", nil},
+		{"unix:@abstract", netUnix, "", 0, "@abstract", nil},
+		{"0.0.0.0:8080", netTcp4, "0.0.0.0", 8080, "", nil},
+		{"[::]:8080", netTcp6, "::", 8080, "", nil},
+		{"tcp://0.0.0.0:8080", netTcp4, "0.0.0.0", 8080, "", nil},
+		{":8080", netTcp, "", 8080, "", nil},
+	}
+
+	for n := range tests {
+		conf, err := listenAddressConfiguration(&Configuration{Mode: netTcp}, tests[n].addr)
+		if !errors.Is(err, tests[n].err) {
+			t.Errorf("функция listenAddressConfiguration(%q), ошибка: %v, ожидалось: %v", tests[n].addr, err, tests[n].err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if conf.Mode != tests[n].mode || conf.Host != tests[n].host && tests[n].host != "" ||
+			conf.Port != tests[n].port || conf.Socket != tests[n].socket {
+			t.Errorf(
+				"функция listenAddressConfiguration(%q), вернулось: %q %q %d %q, ожидалось: %q %q %d %q",
+				tests[n].addr, conf.Mode, conf.Host, conf.Port, conf.Socket,
+				tests[n].mode, tests[n].host, tests[n].port, tests[n].socket,
+			)
+		}
+	}
+}
+
+func TestListenMultiple(t *testing.T) {
+	var (
+		err    error
+		socket string
+		nut    Interface
+		addrs  []net.Addr
+	)
+
+	socket = filepath.Join(t.TempDir(), "multi.sock")
+	nut = New().Handler(testEchoHandler)
+	if err = nut.ListenAndServeWithConfig(&Configuration{
+		Listen: []string{"127.0.0.1:0", "unix:" + socket},
+	}).Error(); err != nil {
+		t.Fatalf("функция ListenAndServeWithConfig(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer nut.Stop()
+	addrs = nut.(*impl).listener.Tcp().(*multiListener).Addrs()
+	if len(addrs) != 2 {
+		t.Fatalf("количество адресов: %d, ожидалось: %d", len(addrs), 2)
+	}
+	for n := range addrs {
+		if ret := testEchoRequest(t, addrs[n], "ping"); ret != "ping" {
+			t.Errorf("ответ сервера на адресе %q: %q, ожидалось: %q", addrs[n], ret, "ping")
+		}
+	}
+	nut.Stop()
+	if _, err = os.Stat(socket); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("сокет %q не удалён после остановки сервера, ошибка: %v", socket, err)
+	}
+}
+
+// Адреса всех интерфейсов IPv4 и IPv6 на одном порту открываются одновременно, в любом порядке.
+func TestListenMultipleDualStack(t *testing.T) {
+	var (
+		err  error
+		ltn  net.Listener
+		port string
+	)
+
+	if ltn, err = net.Listen(netTcp6, "[::1]:0"); err != nil {
+		t.Skipf("IPv6 не доступен: %v", err)
+	}
+	_, port, _ = net.SplitHostPort(ltn.Addr().String())
+	_ = ltn.Close()
+	for _, listen := range [][]string{
+		{"0.0.0.0:" + port, "[::]:" + port},
+		{"[::]:" + port, "0.0.0.0:" + port},
+		{"0.0.0.0:" + port, "tcp6://[::]:" + port},
+	} {
+		nut := New().Handler(testEchoHandler)
+		if err = nut.ListenAndServeWithConfig(&Configuration{Listen: listen}).Error(); err != nil {
+			t.Fatalf("функция ListenAndServeWithConfig(%q), ошибка: %v, ожидалось: %v", listen, err, nil)
+		}
+		for _, addr := range []string{"127.0.0.1:" + port, "[::1]:" + port} {
+			tcp, _ := net.ResolveTCPAddr(netTcp, addr)
+			if ret := testEchoRequest(t, tcp, "ping"); ret != "ping" {
+				t.Errorf("ответ сервера на адресе %q: %q, ожидалось: %q", addr, ret, "ping")
+			}
+		}
+		nut.Stop()
+	}
+}
+
+// При ошибке открытия одного из адресов, все открытые адреса закрываются.
+func TestListenMultipleRollback(t *testing.T) {
+	var (
+		err    error
+		socket string
+		busy   net.Listener
+		ltn    net.Listener
+	)
+
+	socket = filepath.Join(t.TempDir(), "rollback.sock")
+	if busy, err = net.Listen("tcp", "127.0.0.1:0"); err != nil {
+		t.Fatalf("функция Listen(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer func() { _ = busy.Close() }()
+	if ltn, _, err = New().NewListener(&Configuration{
+		Listen: []string{socket, busy.Addr().String()},
+	}); err == nil {
+		_ = ltn.Close()
+		t.Fatalf("функция NewListener(), ошибка: %v, ожидалась ошибка", err)
+	}
+	if _, err = os.Stat(socket); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("сокет %q не удалён после отката, ошибка: %v", socket, err)
+	}
+	if _, _, err = New().NewListener(&Configuration{Mode: netUdp, Listen: []string{"127.0.0.1:0"}}); !errors.Is(
+		err, Errors().ListenUdpMultiple(),
+	) {
+		t.Errorf("функция NewListener(), ошибка: %v, ожидалось: %v", err, Errors().ListenUdpMultiple())
+	}
+}
@@ -0,0 +1,18 @@
+package net
+
+import "net"
+
+// IsMultipathTCP Возвращается истина, если соединение использует Multipath TCP (MPTCP).
+// Соединение может быть обёрнуто в TLS, прокси-протокол или соединение мультиплексора, проверяется исходное TCP
+// соединение. Для соединений, принятых без MPTCP, в том числе при отсутствии поддержки MPTCP ядром операционной
+// системы или клиентом, возвращается ложь.
+func IsMultipathTCP(conn net.Conn) (ret bool) {
+	for ; conn != nil; conn = unwrapConn(conn) {
+		if tcp, ok := conn.(*net.TCPConn); ok {
+			ret, _ = tcp.MultipathTCP()
+			return
+		}
+	}
+
+	return
+}
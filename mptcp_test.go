@@ -0,0 +1,55 @@
+package net
+
+import (
+	"net"
+	"testing"
+)
+
+func TestListenMultipathTCP(t *testing.T) {
+	var (
+		err    error
+		nut    Interface
+		lcf    *net.ListenConfig
+		dialer *net.Dialer
+		conn   net.Conn
+		result chan bool
+	)
+
+	if lcf, err = listenConfig(&Configuration{Mode: netTcp, MultipathTCP: true}); err != nil {
+		t.Fatalf("функция listenConfig(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	if !lcf.MultipathTCP() {
+		t.Errorf("функция listenConfig(), MPTCP: %t, ожидалось: %t", lcf.MultipathTCP(), true)
+	}
+	result = make(chan bool, 1)
+	nut = New().Handler(func(ltn net.Listener) (err error) {
+		var c net.Conn
+
+		for {
+			if c, err = ltn.Accept(); err != nil {
+				return
+			}
+			result <- IsMultipathTCP(c)
+			_ = c.Close()
+		}
+	})
+	if err = nut.ListenAndServeWithConfig(&Configuration{Host: "127.0.0.1", MultipathTCP: true}).Error(); err != nil {
+		t.Fatalf("функция ListenAndServeWithConfig(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer nut.Stop()
+	dialer = new(net.Dialer)
+	dialer.SetMultipathTCP(true)
+	if conn, err = dialer.Dial(nut.Addr().Network(), nut.Addr().String()); err != nil {
+		t.Fatalf("функция Dial(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer func() { _ = conn.Close() }()
+	// При отсутствии поддержки MPTCP ядром соединение устанавливается по обычному TCP.
+	if ret := <-result; ret != IsMultipathTCP(conn) {
+		t.Errorf("функция IsMultipathTCP(), сервер: %t, клиент: %t, ожидалось совпадение", ret, IsMultipathTCP(conn))
+	}
+	c1, c2 := net.Pipe()
+	defer func() { _, _ = c1.Close(), c2.Close() }()
+	if IsMultipathTCP(c1) {
+		t.Errorf("функция IsMultipathTCP(), вернулось: %t, ожидалось: %t", true, false)
+	}
+}
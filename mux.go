@@ -0,0 +1,277 @@
+package net
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+// NewMux Конструктор разделителя соединений по протоколам на одном слушателе.
+// peekTimeout - максимальное время ожидания начальных байтов соединения, если равно 0, используется 10 секунд.
+// Соединение протокола, в котором первым передаёт данные сервер, передаётся в слушатель MuxMatchAny сразу, только
+// если все остальные функции проверки отклонили пустые начальные байты. Если одна из функций проверки ожидает
+// данные (например, MuxMatchTLS), такое соединение передаётся в слушатель MuxMatchAny по истечении peekTimeout.
+func NewMux(ltn net.Listener, peekTimeout time.Duration) Mux {
+	if peekTimeout <= 0 {
+		peekTimeout = muxDefaultPeekTimeout
+	}
+
+	return &mux{
+		root:        ltn,
+		peekSize:    muxDefaultPeekSize,
+		peekTimeout: peekTimeout,
+		lck:         new(sync.RWMutex),
+		done:        make(chan struct{}),
+		once:        new(sync.Once),
+	}
+}
+
+// Match Создание слушателя для соединений, начальные байты которых соответствуют одной из функций проверки.
+// Слушатели проверяются в порядке создания.
+func (mux *mux) Match(matchers ...MuxMatcher) net.Listener {
+	var route *muxRoute
+
+	route = &muxRoute{
+		matchers: matchers,
+		listener: &muxListener{
+			addr:  mux.root.Addr(),
+			conns: make(chan net.Conn),
+			done:  make(chan struct{}),
+			once:  new(sync.Once),
+		},
+	}
+	mux.lck.Lock()
+	mux.routes = append(mux.routes, route)
+	mux.lck.Unlock()
+
+	return route.listener
+}
+
+// Serve Запуск приёма и разделения соединений. Функция блокируется до закрытия слушателя.
+// Ошибки нехватки ресурсов при приёме соединения не завершают функцию, приём соединений повторяется с задержкой.
+func (mux *mux) Serve() (err error) {
+	var (
+		conn  net.Conn
+		ne    net.Error
+		delay time.Duration
+		retry bool
+	)
+
+	defer mux.closeRoutes()
+	for {
+		if conn, err = mux.root.Accept(); err != nil {
+			if errors.As(err, &ne) && ne.Timeout() {
+				continue
+			}
+			if delay, retry = acceptRetryDelay(err, delay); retry {
+				time.Sleep(delay)
+				continue
+			}
+			select {
+			case <-mux.done:
+				err = nil
+			default:
+			}
+			return
+		}
+		delay = 0
+		go mux.route(conn)
+	}
+}
+
+// Close Закрытие исходного слушателя и всех созданных слушателей.
+func (mux *mux) Close() (err error) {
+	mux.once.Do(func() { close(mux.done) })
+	err = mux.root.Close()
+	mux.closeRoutes()
+
+	return
+}
+
+// Закрытие всех созданных слушателей.
+func (mux *mux) closeRoutes() {
+	var n int
+
+	mux.lck.RLock()
+	defer mux.lck.RUnlock()
+	for n = range mux.routes {
+		_ = mux.routes[n].listener.Close()
+	}
+}
+
+// Чтение начальных байтов соединения и передача соединения в соответствующий слушатель.
+// Если ни одна функция проверки не подтвердила соответствие протоколу, соединение закрывается.
+func (mux *mux) route(conn net.Conn) {
+	var (
+		head  []byte
+		n     int
+		err   error
+		route *muxRoute
+		more  bool
+		final bool
+	)
+
+	head = make([]byte, 0, muxPeekSizeInitial)
+	_ = conn.SetReadDeadline(time.Now().Add(mux.peekTimeout))
+	for {
+		if route, more = mux.match(head, final); route != nil || !more || final {
+			break
+		}
+		if len(head) == cap(head) {
+			head = append(make([]byte, 0, min(cap(head)*2, mux.peekSize)), head...)
+		}
+		if n, err = conn.Read(head[len(head):cap(head)]); n > 0 {
+			head = head[:len(head)+n]
+		}
+		final = err != nil || len(head) == mux.peekSize
+	}
+	_ = conn.SetReadDeadline(time.Time{})
+	if route == nil {
+		_ = conn.Close()
+		return
+	}
+	route.listener.push(&muxConn{Conn: conn, peek: head, head: head})
+}
+
+// Поиск слушателя по начальным байтам соединения.
+// Возвращается истина, если для принятия решения необходимо больше данных. Если данных больше не будет (final),
+// выбирается первый слушатель, подтвердивший соответствие протоколу.
+func (mux *mux) match(head []byte, final bool) (ret *muxRoute, more bool) {
+	var n, m int
+
+	mux.lck.RLock()
+	defer mux.lck.RUnlock()
+	for n = range mux.routes {
+		for m = range mux.routes[n].matchers {
+			switch mux.routes[n].matchers[m](head) {
+			case MuxMatch:
+				if !more || final {
+					ret = mux.routes[n]
+					return
+				}
+			case MuxNeedMore:
+				more = true
+			}
+		}
+	}
+
+	return
+}
+
+// Передача соединения в Accept слушателя, если слушатель закрыт, соединение закрывается.
+func (mul *muxListener) push(conn net.Conn) {
+	select {
+	case mul.conns <- conn:
+	case <-mul.done:
+		_ = conn.Close()
+	}
+}
+
+// Accept Ожидание соединения, соответствующего протоколу слушателя.
+func (mul *muxListener) Accept() (ret net.Conn, err error) {
+	select {
+	case ret = <-mul.conns:
+	case <-mul.done:
+		err = net.ErrClosed
+	}
+
+	return
+}
+
+// Close Закрытие слушателя, исходный слушатель не закрывается.
+func (mul *muxListener) Close() error { mul.once.Do(func() { close(mul.done) }); return nil }
+
+// Addr Адрес исходного слушателя.
+func (mul *muxListener) Addr() net.Addr { return mul.addr }
+
+// Read Чтение данных, сначала отдаются начальные байты, прочитанные разделителем.
+func (muc *muxConn) Read(b []byte) (n int, err error) {
+	if len(muc.head) > 0 {
+		n = copy(b, muc.head)
+		muc.head = muc.head[n:]
+		return
+	}
+
+	return muc.Conn.Read(b)
+}
+
+// NetConn Исходное соединение.
+func (muc *muxConn) NetConn() net.Conn { return muc.Conn }
+
+// Проверка начальных байтов на соответствие одному из префиксов.
+func matchPrefix(head []byte, prefixes ...[]byte) (ret MuxResult) {
+	var n, size int
+
+	for n = range prefixes {
+		switch size = len(prefixes[n]); {
+		case len(head) >= size && bytes.Equal(head[:size], prefixes[n]):
+			return MuxMatch
+		case len(head) < size && bytes.Equal(head, prefixes[n][:len(head)]):
+			ret = MuxNeedMore
+		}
+	}
+
+	return
+}
+
+// MuxMatchPrefix Функция проверки соответствия начальных байтов соединения одному из префиксов.
+func MuxMatchPrefix(prefixes ...string) MuxMatcher {
+	var (
+		src [][]byte
+		n   int
+	)
+
+	src = make([][]byte, len(prefixes))
+	for n = range prefixes {
+		src[n] = []byte(prefixes[n])
+	}
+
+	return func(head []byte) MuxResult { return matchPrefix(head, src...) }
+}
+
+// MuxMatchAny Функция проверки, соответствующая любому соединению, в том числе соединению без данных.
+// Используется для слушателя по умолчанию, создаваемого последним. Соединение без данных передаётся в слушатель
+// после ожидания начальных байтов, если другая функция проверки ожидает данные, см. NewMux.
+func MuxMatchAny() MuxMatcher { return func(_ []byte) MuxResult { return MuxMatch } }
+
+// MuxMatchTLS Функция проверки соединения TLS, начальные байты являются записью TLS ClientHello.
+func MuxMatchTLS() MuxMatcher {
+	const (
+		recordTypeHandshake = 0x16
+		versionMajor        = 0x03
+		versionMinorMax     = 0x04
+	)
+
+	return func(head []byte) MuxResult {
+		switch {
+		case len(head) > 0 && head[0] != recordTypeHandshake,
+			len(head) > 1 && head[1] != versionMajor,
+			len(head) > 2 && head[2] > versionMinorMax:
+			return MuxMismatch
+		case len(head) < 3:
+			return MuxNeedMore
+		default:
+			return MuxMatch
+		}
+	}
+}
+
+// MuxMatchHTTP1 Функция проверки соединения HTTP/1.x, начальные байты являются методом HTTP запроса.
+func MuxMatchHTTP1() MuxMatcher {
+	return MuxMatchPrefix(
+		"GET ", "HEAD ", "POST ", "PUT ", "DELETE ", "OPTIONS ", "PATCH ", "CONNECT ", "TRACE ",
+	)
+}
+
+// MuxMatchHTTP2 Функция проверки соединения HTTP/2 без TLS, начальные байты являются преамбулой HTTP/2.
+func MuxMatchHTTP2() MuxMatcher { return MuxMatchPrefix("PRI * HTTP/2.0\r\n\r\nSM\r\n\r\n") }
+
+// MuxMatchSSH Функция проверки соединения SSH, начальные байты являются идентификатором протокола SSH.
+func MuxMatchSSH() MuxMatcher { return MuxMatchPrefix("SSH-") }
+
+// MuxMatchProxyProtocol Функция проверки соединения начинающегося с заголовка прокси-протокола версии 1 или 2.
+func MuxMatchProxyProtocol() MuxMatcher {
+	return MuxMatchPrefix("PROXY ", "\r\n\r\n\x00\r\nQUIT\n")
+}
@@ -0,0 +1,180 @@
+package net
+
+import (
+	"errors"
+	"io"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestMuxMatchers(t *testing.T) {
+	var tests = []struct {
+		name    string
+		matcher MuxMatcher
+		head    string
+		result  MuxResult
+	}{
+		{"TLS", MuxMatchTLS(), "\x16\x03\x01\x02\x00", MuxMatch},
+		{"TLS", MuxMatchTLS(), "\x16", MuxNeedMore},
+		{"TLS", MuxMatchTLS(), "GET ", MuxMismatch},
+		{"HTTP1", MuxMatchHTTP1(), "GET / HTTP/1.1\r\n", MuxMatch},
+		{"HTTP1", MuxMatchHTTP1(), "PO", MuxNeedMore},
+		{"HTTP1", MuxMatchHTTP1(), "PRI * ", MuxMismatch},
+		{"HTTP2", MuxMatchHTTP2(), "PRI * HTTP/2.0\r\n\r\nSM\r\n\r\n", MuxMatch},
+		{"HTTP2", MuxMatchHTTP2(), "PRI * HTTP/2.0", MuxNeedMore},
+		{"SSH", MuxMatchSSH(), "SSH-2.0-OpenSSH_9.0\r\n", MuxMatch},
+		{"SSH", MuxMatchSSH(), "SSX", MuxMismatch},
+		{"PROXY", MuxMatchProxyProtocol(), "PROXY TCP4 ", MuxMatch},
+		{"PROXY", MuxMatchProxyProtocol(), "\r\n\r\n\x00\r\nQUIT\n\x21", MuxMatch},
+		{"PROXY", MuxMatchProxyProtocol(), "\r\n\r\n", MuxNeedMore},
+		{"Prefix", MuxMatchPrefix("MYPROTO1", "MYPROTO2"), "MYPROTO2 hello", MuxMatch},
+		{"Any", MuxMatchAny(), "", MuxMatch},
+	}
+
+	for n := range tests {
+		if ret := tests[n].matcher([]byte(tests[n].head)); ret != tests[n].result {
+			t.Errorf("функция MuxMatch%s(%q), вернулось: %d, ожидалось: %d",
+				tests[n].name, tests[n].head, ret, tests[n].result)
+		}
+	}
+}
+
+func TestMux(t *testing.T) {
+	var (
+		err   error
+		root  net.Listener
+		mux   Mux
+		lst   [3]net.Listener
+		conn  net.Conn
+		buf   []byte
+		done  chan error
+		tests = []struct {
+			data  string
+			route int
+		}{
+			{"GET / HTTP/1.1\r\n\r\n", 1},
+			{"SSH-2.0-test\r\n", 0},
+			{"MYPROTO hello", 2},
+			{"", 2},
+		}
+	)
+
+	if root, err = net.Listen("tcp", "127.0.0.1:0"); err != nil {
+		t.Fatalf("функция Listen(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	mux = NewMux(root, time.Millisecond*200)
+	lst[0] = mux.Match(MuxMatchSSH())
+	lst[1] = mux.Match(MuxMatchHTTP1(), MuxMatchHTTP2())
+	lst[2] = mux.Match(MuxMatchAny())
+	done = make(chan error, 1)
+	go func() { done <- mux.Serve() }()
+	for n := range tests {
+		if conn, err = net.Dial("tcp", root.Addr().String()); err != nil {
+			t.Fatalf("функция Dial(), ошибка: %v, ожидалось: %v", err, nil)
+		}
+		if _, err = conn.Write([]byte(tests[n].data)); err != nil {
+			t.Fatalf("функция Write(), ошибка: %v, ожидалось: %v", err, nil)
+		}
+		_ = conn.(*net.TCPConn).CloseWrite()
+		if conn, err = lst[tests[n].route].Accept(); err != nil {
+			t.Fatalf("функция Accept(), ошибка: %v, ожидалось: %v", err, nil)
+		}
+		if buf, err = io.ReadAll(conn); err != nil || string(buf) != tests[n].data {
+			t.Errorf("функция ReadAll(), вернулось: %q, ошибка: %v, ожидалось: %q, %v", buf, err, tests[n].data, nil)
+		}
+		_ = conn.Close()
+	}
+	if err = mux.Close(); err != nil {
+		t.Errorf("функция Close(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	if err = <-done; err != nil {
+		t.Errorf("функция Serve(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	if _, err = lst[0].Accept(); !errors.Is(err, net.ErrClosed) {
+		t.Errorf("функция Accept(), ошибка: %v, ожидалось: %v", err, net.ErrClosed)
+	}
+}
+
+// Соединение протокола, в котором первым передаёт данные сервер, передаётся в слушатель MuxMatchAny после
+// ожидания начальных байтов, так как MuxMatchTLS ожидает данные.
+func TestMuxServerFirst(t *testing.T) {
+	const peekTimeout = time.Millisecond * 200
+	var (
+		err   error
+		root  net.Listener
+		mux   Mux
+		lst   net.Listener
+		conn  net.Conn
+		srv   net.Conn
+		buf   []byte
+		start time.Time
+	)
+
+	if root, err = net.Listen("tcp", "127.0.0.1:0"); err != nil {
+		t.Fatalf("функция Listen(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	mux = NewMux(root, peekTimeout)
+	defer func() { _ = mux.Close() }()
+	_ = mux.Match(MuxMatchTLS())
+	lst = mux.Match(MuxMatchAny())
+	go func() { _ = mux.Serve() }()
+	start = time.Now()
+	if conn, err = net.Dial("tcp", root.Addr().String()); err != nil {
+		t.Fatalf("функция Dial(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer func() { _ = conn.Close() }()
+	if srv, err = lst.Accept(); err != nil {
+		t.Fatalf("функция Accept(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	if elapsed := time.Since(start); elapsed < peekTimeout {
+		t.Errorf("соединение передано через %s, ожидалось не ранее %s", elapsed, peekTimeout)
+	}
+	_, _ = srv.Write([]byte("220 ready\r\n"))
+	_ = srv.Close()
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+	if buf, err = io.ReadAll(conn); err != nil || string(buf) != "220 ready\r\n" {
+		t.Errorf("функция ReadAll(), вернулось: %q, ошибка: %v, ожидалось: %q", buf, err, "220 ready\r\n")
+	}
+}
+
+// Ошибки нехватки ресурсов при приёме соединения не завершают разделитель, остальные ошибки завершают.
+func TestMuxAcceptRetry(t *testing.T) {
+	var (
+		err  error
+		root net.Listener
+		mux  Mux
+		lst  net.Listener
+		conn net.Conn
+		done chan error
+	)
+
+	if root, err = net.Listen("tcp", "127.0.0.1:0"); err != nil {
+		t.Fatalf("функция Listen(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	mux = NewMux(&testProxyFailingListener{Listener: root, fails: 3, errno: syscall.EMFILE}, time.Second)
+	lst = mux.Match(MuxMatchAny())
+	done = make(chan error, 1)
+	go func() { done <- mux.Serve() }()
+	if conn, err = net.Dial("tcp", root.Addr().String()); err != nil {
+		t.Fatalf("функция Dial(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	_ = conn.Close()
+	if conn, err = lst.Accept(); err != nil {
+		t.Fatalf("функция Accept(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	_ = conn.Close()
+	_ = mux.Close()
+	if err = <-done; err != nil {
+		t.Errorf("функция Serve(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	if root, err = net.Listen("tcp", "127.0.0.1:0"); err != nil {
+		t.Fatalf("функция Listen(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer func() { _ = root.Close() }()
+	mux = NewMux(&testProxyFailingListener{Listener: root, fails: 1, errno: syscall.EINVAL}, time.Second)
+	if err = mux.Serve(); !errors.Is(err, syscall.EINVAL) {
+		t.Errorf("функция Serve(), ошибка: %v, ожидалось: %v", err, syscall.EINVAL)
+	}
+}
@@ -0,0 +1,234 @@
+package net
+
+import (
+	"net"
+	"strings"
+
+	"golang.org/x/crypto/cryptobyte"
+)
+
+// Константы протокола TLS, необходимые для разбора ClientHello.
+const (
+	tlsRecordHeaderLength      = 5      // Размер заголовка записи TLS.
+	tlsHandshakeClientHello    = 0x01   // Тип сообщения рукопожатия - ClientHello.
+	tlsExtensionServerName     = 0x0000 // Расширение SNI.
+	tlsExtensionALPN           = 0x0010 // Расширение ALPN.
+	tlsServerNameTypeHostName  = 0x00   // Тип имени сервера в расширении SNI.
+	tlsClientHelloRandomLength = 32     // Размер случайного числа клиента.
+)
+
+// MuxMatchSNI Функция проверки соединения TLS по имени сервера (SNI) без завершения TLS.
+// Имя сравнивается без учёта регистра, шаблон вида "*.example.com" соответствует одному уровню поддомена,
+// пустой шаблон соответствует соединению без SNI.
+func MuxMatchSNI(names ...string) MuxMatcher {
+	return func(head []byte) (ret MuxResult) {
+		var (
+			hello *MuxTLSClientHello
+			n     int
+		)
+
+		if hello, ret = parseTLSClientHello(head); ret != MuxMatch {
+			return
+		}
+		for n = range names {
+			if matchServerName(names[n], hello.ServerName) {
+				return MuxMatch
+			}
+		}
+
+		return MuxMismatch
+	}
+}
+
+// MuxMatchALPN Функция проверки соединения TLS по протоколам прикладного уровня (ALPN) без завершения TLS.
+// Соединение соответствует, если клиент предлагает хотя бы один из перечисленных протоколов.
+func MuxMatchALPN(protocols ...string) MuxMatcher {
+	return func(head []byte) (ret MuxResult) {
+		var (
+			hello *MuxTLSClientHello
+			n, m  int
+		)
+
+		if hello, ret = parseTLSClientHello(head); ret != MuxMatch {
+			return
+		}
+		for n = range hello.Protocols {
+			for m = range protocols {
+				if hello.Protocols[n] == protocols[m] {
+					return MuxMatch
+				}
+			}
+		}
+
+		return MuxMismatch
+	}
+}
+
+// MuxClientHello Данные TLS ClientHello соединения, выделенного разделителем.
+// Возвращается nil, если соединение получено не из разделителя или не является соединением TLS.
+// Используется обработчиками, выполняющими перенаправление соединения TLS без его завершения.
+func MuxClientHello(conn net.Conn) (ret *MuxTLSClientHello) {
+	var (
+		muc *muxConn
+		ok  bool
+	)
+
+	for ; conn != nil && !ok; conn = unwrapConn(conn) {
+		muc, ok = conn.(*muxConn)
+	}
+	if !ok {
+		return
+	}
+	ret, _ = parseTLSClientHello(muc.peek)
+
+	return
+}
+
+// Проверка имени сервера на соответствие шаблону.
+func matchServerName(pattern string, name string) bool {
+	const wildcard = "*."
+	var suffix string
+
+	pattern, name = strings.ToLower(strings.TrimSuffix(pattern, ".")), strings.ToLower(strings.TrimSuffix(name, "."))
+	if !strings.HasPrefix(pattern, wildcard) {
+		return pattern == name
+	}
+	suffix = pattern[len(wildcard)-1:]
+	if !strings.HasSuffix(name, suffix) || len(name) == len(suffix) {
+		return false
+	}
+
+	return !strings.Contains(name[:len(name)-len(suffix)], ".")
+}
+
+// Разбор TLS ClientHello. ClientHello, разделённый клиентом на несколько записей TLS, собирается из
+// последовательных записей, если все записи помещаются в буфер начальных байтов разделителя (16 КБ), иначе
+// соединение не соответствует проверке и передаётся следующему слушателю.
+// Возвращается MuxNeedMore, если ClientHello прочитан не полностью, и MuxMismatch, если данные не являются
+// ClientHello.
+func parseTLSClientHello(head []byte) (ret *MuxTLSClientHello, result MuxResult) {
+	var (
+		record  cryptobyte.String
+		hsType  uint8
+		body    cryptobyte.String
+		skip    cryptobyte.String
+		exts    cryptobyte.String
+		ext     cryptobyte.String
+		extType uint16
+		hello   *MuxTLSClientHello
+	)
+
+	if result = MuxMatchTLS()(head); result != MuxMatch {
+		return
+	}
+	if record, result = tlsHandshakeMessage(head); result != MuxMatch {
+		return
+	}
+	result = MuxMismatch
+	if !record.ReadUint8(&hsType) || hsType != tlsHandshakeClientHello ||
+		!record.ReadUint24LengthPrefixed(&body) ||
+		!body.Skip(2+tlsClientHelloRandomLength) ||
+		!body.ReadUint8LengthPrefixed(&skip) ||
+		!body.ReadUint16LengthPrefixed(&skip) ||
+		!body.ReadUint8LengthPrefixed(&skip) {
+		return
+	}
+	hello = new(MuxTLSClientHello)
+	if !body.Empty() && !body.ReadUint16LengthPrefixed(&exts) {
+		return
+	}
+	for !exts.Empty() {
+		if !exts.ReadUint16(&extType) || !exts.ReadUint16LengthPrefixed(&ext) {
+			return
+		}
+		switch extType {
+		case tlsExtensionServerName:
+			if !parseTLSServerName(ext, hello) {
+				return
+			}
+		case tlsExtensionALPN:
+			if !parseTLSProtocols(ext, hello) {
+				return
+			}
+		}
+	}
+	ret, result = hello, MuxMatch
+
+	return
+}
+
+// Сборка первого сообщения рукопожатия из последовательных записей TLS.
+func tlsHandshakeMessage(head []byte) (ret []byte, result MuxResult) {
+	const (
+		recordTypeHandshake   = 0x16
+		handshakeHeaderLength = 4
+	)
+	var recordLen, size, msgLen int
+
+	for msgLen = -1; ; head = head[size:] {
+		if len(head) < tlsRecordHeaderLength {
+			result = MuxNeedMore
+			return
+		}
+		if head[0] != recordTypeHandshake {
+			result = MuxMismatch
+			return
+		}
+		recordLen = int(head[3])<<8 | int(head[4])
+		if size = tlsRecordHeaderLength + recordLen; len(head) < size {
+			result = MuxNeedMore
+			return
+		}
+		ret = append(ret, head[tlsRecordHeaderLength:size]...)
+		if msgLen < 0 && len(ret) >= handshakeHeaderLength {
+			msgLen = handshakeHeaderLength + (int(ret[1])<<16 | int(ret[2])<<8 | int(ret[3]))
+		}
+		if msgLen >= 0 && len(ret) >= msgLen {
+			ret, result = ret[:msgLen], MuxMatch
+			return
+		}
+	}
+}
+
+// Разбор расширения SNI.
+func parseTLSServerName(ext cryptobyte.String, hello *MuxTLSClientHello) bool {
+	var (
+		list     cryptobyte.String
+		name     cryptobyte.String
+		nameType uint8
+	)
+
+	if !ext.ReadUint16LengthPrefixed(&list) {
+		return false
+	}
+	for !list.Empty() {
+		if !list.ReadUint8(&nameType) || !list.ReadUint16LengthPrefixed(&name) {
+			return false
+		}
+		if nameType == tlsServerNameTypeHostName && hello.ServerName == "" {
+			hello.ServerName = string(name)
+		}
+	}
+
+	return true
+}
+
+// Разбор расширения ALPN.
+func parseTLSProtocols(ext cryptobyte.String, hello *MuxTLSClientHello) bool {
+	var (
+		list  cryptobyte.String
+		proto cryptobyte.String
+	)
+
+	if !ext.ReadUint16LengthPrefixed(&list) {
+		return false
+	}
+	for !list.Empty() {
+		if !list.ReadUint8LengthPrefixed(&proto) || len(proto) == 0 {
+			return false
+		}
+		hello.Protocols = append(hello.Protocols, string(proto))
+	}
+
+	return true
+}
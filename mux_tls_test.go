@@ -0,0 +1,136 @@
+package net
+
+import (
+	"crypto/tls"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestMatchServerName(t *testing.T) {
+	var tests = []struct {
+		pattern string
+		name    string
+		ok      bool
+	}{
+		{"example.com", "EXAMPLE.com.", true},
+		{"example.com", "www.example.com", false},
+		{"*.example.com", "www.example.com", true},
+		{"*.example.com", "a.b.example.com", false},
+		{"*.example.com", "example.com", false},
+		{"", "", true},
+	}
+
+	for n := range tests {
+		if ok := matchServerName(tests[n].pattern, tests[n].name); ok != tests[n].ok {
+			t.Errorf(
+				"функция matchServerName(%q, %q), вернулось: %t, ожидалось: %t",
+				tests[n].pattern, tests[n].name, ok, tests[n].ok,
+			)
+		}
+	}
+}
+
+// Перенаправление соединений TLS по SNI и ALPN без завершения TLS разделителем.
+func TestMuxSNI(t *testing.T) {
+	var (
+		err    error
+		root   net.Listener
+		mux    Mux
+		lst    [3]net.Listener
+		cert   tls.Certificate
+		conn   net.Conn
+		hello  *MuxTLSClientHello
+		client chan string
+		tests  = []struct {
+			serverName string
+			protocols  []string
+			route      int
+		}{
+			{"a.example.com", []string{"http/1.1"}, 0},
+			{"b.example.com", []string{"h2", "http/1.1"}, 1},
+			{"", nil, 2},
+		}
+	)
+
+	if cert, err = tls.X509KeyPair(getCrtEcdsa(), getKeyEcdsa()); err != nil {
+		t.Fatalf("функция X509KeyPair(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	if root, err = net.Listen("tcp", "127.0.0.1:0"); err != nil {
+		t.Fatalf("функция Listen(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	mux = NewMux(root, time.Second)
+	defer func() { _ = mux.Close() }()
+	lst[0] = mux.Match(MuxMatchSNI("a.example.com"))
+	lst[1] = mux.Match(MuxMatchSNI("*.EXAMPLE.com"), MuxMatchALPN("h2"))
+	lst[2] = mux.Match(MuxMatchTLS())
+	go func() { _ = mux.Serve() }()
+	client = make(chan string, 1)
+	for n := range tests {
+		go func(serverName string, protocols []string) {
+			var data []byte
+			if c, e := tls.Dial("tcp", root.Addr().String(), &tls.Config{
+				InsecureSkipVerify: true,
+				ServerName:         serverName,
+				NextProtos:         protocols,
+			}); e == nil {
+				data, _ = io.ReadAll(c)
+				_ = c.Close()
+			}
+			client <- string(data)
+		}(tests[n].serverName, tests[n].protocols)
+		if conn, err = lst[tests[n].route].Accept(); err != nil {
+			t.Fatalf("функция Accept(), ошибка: %v, ожидалось: %v", err, nil)
+		}
+		if hello = MuxClientHello(conn); hello == nil || hello.ServerName != tests[n].serverName {
+			t.Errorf("функция MuxClientHello(), вернулось: %v, ожидалось SNI: %q", hello, tests[n].serverName)
+		}
+		// Завершение TLS обработчиком, получившим соединение с повторно отдаваемым ClientHello.
+		conn = tls.Server(conn, &tls.Config{Certificates: []tls.Certificate{cert}})
+		if _, err = conn.Write([]byte(tests[n].serverName + "!")); err != nil {
+			t.Errorf("функция Write(), ошибка: %v, ожидалось: %v", err, nil)
+		}
+		_ = conn.Close()
+		if data := <-client; data != tests[n].serverName+"!" {
+			t.Errorf("клиент TLS, получено: %q, ожидалось: %q", data, tests[n].serverName+"!")
+		}
+	}
+}
+
+// ClientHello, разделённый на несколько записей TLS, собирается из последовательных записей.
+func TestParseTLSClientHelloFragmented(t *testing.T) {
+	const serverName = "fragmented.example.com"
+	var (
+		err    error
+		c1, c2 net.Conn
+		head   []byte
+		split  []byte
+		hello  *MuxTLSClientHello
+		result MuxResult
+		n      int
+	)
+
+	c1, c2 = net.Pipe()
+	defer func() { _, _ = c1.Close(), c2.Close() }()
+	go func() { _ = tls.Client(c1, &tls.Config{ServerName: serverName}).Handshake() }()
+	head = make([]byte, muxDefaultPeekSize)
+	_ = c2.SetReadDeadline(time.Now().Add(time.Second))
+	if n, err = c2.Read(head); err != nil {
+		t.Fatalf("функция Read(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	head = head[:n]
+	// Первая запись содержит 10 байтов сообщения рукопожатия, вторая запись - остальные байты.
+	n = tlsRecordHeaderLength + 10
+	split = append(split, head[0], head[1], head[2], 0, 10)
+	split = append(split, head[tlsRecordHeaderLength:n]...)
+	split = append(split, head[0], head[1], head[2], byte((len(head)-n)>>8), byte(len(head)-n))
+	split = append(split, head[n:]...)
+	if _, result = parseTLSClientHello(split[:n+3]); result != MuxNeedMore {
+		t.Errorf("функция parseTLSClientHello(), вернулось: %v, ожидалось: %v", result, MuxNeedMore)
+	}
+	if hello, result = parseTLSClientHello(split); result != MuxMatch || hello.ServerName != serverName {
+		t.Errorf("функция parseTLSClientHello(), вернулось: %v, %+v, ожидалось: %v, %q",
+			result, hello, MuxMatch, serverName)
+	}
+}
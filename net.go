@@ -10,12 +10,15 @@ import (
 // New Конструктор объекта сущности пакета, возвращается интерфейс пакета.
 func New() Interface {
 	var nut = &impl{
-		lck:        new(sync.Mutex),
-		isRun:      new(atomic.Bool),
-		isShutdown: new(atomic.Bool),
-		fnFl:       net.FileListener,
-		fnNf:       os.NewFile,
-		fnFc:       fileClose,
+		lck:         new(sync.Mutex),
+		isRun:       new(atomic.Bool),
+		isShutdown:  new(atomic.Bool),
+		fnFl:        net.FileListener,
+		fnNf:        os.NewFile,
+		fnFc:        fileClose,
+		tlsFailures: newHandshakeFailures(),
+		tcpInfo:     newTCPInfoMetrics(),
+		stderr:      os.Stderr,
 	}
 
 	nut.isRun.Store(false)
@@ -61,6 +64,8 @@ func (nut *impl) Stop() Interface {
 	// Защита от возможной смертельной блокировки при остановке сервера из разных потоков.
 	nut.lck.Lock()
 	defer nut.lck.Unlock()
+	// Сброс TLS конфигурации переключения соединений в режим TLS (STARTTLS).
+	nut.startTLSReset()
 	// Выход, если сервер не запущен или уже начато завершение работы сервера.
 	if !nut.isRun.Load() || nut.isShutdown.Load() {
 		return nut
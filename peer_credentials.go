@@ -0,0 +1,73 @@
+package net
+
+import "net"
+
+// ConnPeerCredentials Учётные данные процесса клиента юникс-сокета: PID, UID, GID и метка безопасности.
+// Соединение может быть обёрнуто в TLS, прокси-протокол или соединение мультиплексора, учётные данные читаются из
+// исходного соединения юникс-сокета. Только для Linux.
+func ConnPeerCredentials(conn net.Conn) (ret *PeerCredentials, err error) {
+	for ; conn != nil; conn = unwrapConn(conn) {
+		if uc, ok := conn.(*net.UnixConn); ok {
+			ret, err = peerCredentials(uc)
+			return
+		}
+	}
+	err = Errors().PeerCredentialsConn()
+
+	return
+}
+
+// Включение проверки учётных данных процесса клиента юникс-сокета, если в конфигурации сервера указан список
+// разрешённых пользователей или групп.
+func peerCredentialsListener(conf *Configuration, ltn net.Listener) (ret net.Listener) {
+	var n int
+
+	if ret = ltn; ret == nil || len(conf.SocketPeerUID) == 0 && len(conf.SocketPeerGID) == 0 {
+		return
+	}
+	pcl := &peerListener{Listener: ltn, uid: make(map[uint32]bool), gid: make(map[uint32]bool)}
+	for n = range conf.SocketPeerUID {
+		pcl.uid[conf.SocketPeerUID[n]] = true
+	}
+	for n = range conf.SocketPeerGID {
+		pcl.gid[conf.SocketPeerGID[n]] = true
+	}
+	ret = pcl
+
+	return
+}
+
+// Accept Ожидание соединения. Соединения юникс-сокета от не разрешённых пользователей и групп, а так же
+// соединения, учётные данные которых не удалось получить, закрываются без передачи в сервер. Соединения TCP/IP
+// передаются без проверки.
+func (pcl *peerListener) Accept() (ret net.Conn, err error) {
+	for {
+		if ret, err = pcl.Listener.Accept(); err != nil {
+			return
+		}
+		if pcl.isAllowed(ret) {
+			return
+		}
+		_ = ret.Close()
+	}
+}
+
+// Проверка учётных данных процесса клиента по спискам разрешённых пользователей и групп.
+// SO_PEERCRED передаёт только основную группу процесса (GID), дополнительные группы процесса не проверяются.
+func (pcl *peerListener) isAllowed(conn net.Conn) bool {
+	var (
+		uc   *net.UnixConn
+		cred *PeerCredentials
+		err  error
+		ok   bool
+	)
+
+	if uc, ok = conn.(*net.UnixConn); !ok {
+		return true
+	}
+	if cred, err = peerCredentials(uc); err != nil {
+		return false
+	}
+
+	return pcl.uid[cred.UID] || pcl.gid[cred.GID]
+}
@@ -0,0 +1,46 @@
+//go:build linux
+
+package net
+
+import (
+	"net"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// Учётные данные процесса клиента юникс-сокета поддерживаются.
+const peerCredentialsSupported = true
+
+// Чтение учётных данных процесса клиента юникс-сокета опциями SO_PEERCRED и SO_PEERSEC.
+// Метка безопасности читается по возможности: если модуль безопасности ядра не назначает меток или метку не удалось
+// прочитать, метка остаётся пустой, а учётные данные возвращаются без ошибки.
+func peerCredentials(uc *net.UnixConn) (ret *PeerCredentials, err error) {
+	var (
+		raw  syscall.RawConn
+		cred *unix.Ucred
+	)
+
+	if raw, err = uc.SyscallConn(); err != nil {
+		return
+	}
+	ret = new(PeerCredentials)
+	if e := raw.Control(func(fd uintptr) {
+		if cred, err = unix.GetsockoptUcred(int(fd), unix.SOL_SOCKET, unix.SO_PEERCRED); err != nil {
+			return
+		}
+		// Метка безопасности не используется при проверке доступа, ошибка её чтения не является ошибкой.
+		if label, e := unix.GetsockoptString(int(fd), unix.SOL_SOCKET, unix.SO_PEERSEC); e == nil {
+			ret.SecurityLabel = label
+		}
+	}); e != nil {
+		err = e
+	}
+	if err != nil {
+		ret = nil
+		return
+	}
+	ret.PID, ret.UID, ret.GID = cred.Pid, cred.Uid, cred.Gid
+
+	return
+}
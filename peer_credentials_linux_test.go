@@ -0,0 +1,87 @@
+//go:build linux
+
+package net
+
+import (
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConnPeerCredentials(t *testing.T) {
+	var (
+		err    error
+		socket string
+		ltn    net.Listener
+		client net.Conn
+		conn   net.Conn
+		cred   *PeerCredentials
+	)
+
+	socket = filepath.Join(t.TempDir(), "peer.sock")
+	if ltn, _, err = New().NewListener(&Configuration{Mode: netUnix, Socket: socket}); err != nil {
+		t.Fatalf("функция NewListener(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer func() { _ = ltn.Close() }()
+	if client, err = net.Dial(netUnix, socket); err != nil {
+		t.Fatalf("функция Dial(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer func() { _ = client.Close() }()
+	if conn, err = ltn.Accept(); err != nil {
+		t.Fatalf("функция Accept(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer func() { _ = conn.Close() }()
+	if cred, err = ConnPeerCredentials(conn); err != nil {
+		t.Fatalf("функция ConnPeerCredentials(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	if cred.PID != int32(os.Getpid()) || cred.UID != uint32(os.Getuid()) || cred.GID != uint32(os.Getgid()) {
+		t.Errorf(
+			"функция ConnPeerCredentials(), вернулось: %+v, ожидалось: PID %d, UID %d, GID %d",
+			cred, os.Getpid(), os.Getuid(), os.Getgid(),
+		)
+	}
+}
+
+// Соединения принимаются только от процессов разрешённых пользователей и групп.
+func TestListenUnixPeerAllowlist(t *testing.T) {
+	var tests = []struct {
+		conf  Configuration
+		allow bool
+	}{
+		{Configuration{SocketPeerUID: []uint32{uint32(os.Getuid())}}, true},
+		{Configuration{SocketPeerGID: []uint32{uint32(os.Getgid())}}, true},
+		{Configuration{SocketPeerUID: []uint32{uint32(os.Getuid()) + 1}}, false},
+		{
+			Configuration{SocketPeerUID: []uint32{uint32(os.Getuid()) + 1}, SocketPeerGID: []uint32{uint32(os.Getgid())}},
+			true,
+		},
+	}
+
+	for n := range tests {
+		var (
+			err  error
+			nut  Interface
+			conn net.Conn
+			buf  []byte
+		)
+
+		tests[n].conf.Mode, tests[n].conf.Socket = netUnix, filepath.Join(t.TempDir(), "peer.sock")
+		nut = New().Handler(testEchoHandler)
+		if err = nut.ListenAndServeWithConfig(&tests[n].conf).Error(); err != nil {
+			t.Fatalf("функция ListenAndServeWithConfig(), ошибка: %v, ожидалось: %v", err, nil)
+		}
+		if conn, err = net.Dial(netUnix, tests[n].conf.Socket); err != nil {
+			t.Fatalf("функция Dial(), ошибка: %v, ожидалось: %v", err, nil)
+		}
+		_, _ = conn.Write([]byte("ping"))
+		_ = closeWrite(conn)
+		buf, _ = io.ReadAll(conn)
+		if allow := string(buf) == "ping"; allow != tests[n].allow {
+			t.Errorf("тест %d, ответ сервера: %q, соединение разрешено: %t, ожидалось: %t", n, buf, allow, tests[n].allow)
+		}
+		_ = conn.Close()
+		nut.Stop()
+	}
+}
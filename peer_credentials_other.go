@@ -0,0 +1,15 @@
+//go:build !linux
+
+package net
+
+import "net"
+
+// Учётные данные процесса клиента юникс-сокета не поддерживаются, списки SocketPeerUID и SocketPeerGID являются
+// ошибкой конфигурации.
+const peerCredentialsSupported = false
+
+// Учётные данные процесса клиента юникс-сокета поддерживаются только в Linux.
+func peerCredentials(_ *net.UnixConn) (ret *PeerCredentials, err error) {
+	err = Errors().SocketOptionNotSupported()
+	return
+}
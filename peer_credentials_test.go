@@ -0,0 +1,63 @@
+package net
+
+import (
+	"errors"
+	"net"
+	"testing"
+)
+
+func TestConnPeerCredentialsNotUnix(t *testing.T) {
+	var (
+		err  error
+		ltn  net.Listener
+		conn net.Conn
+	)
+
+	if ltn, err = net.Listen(netTcp, "127.0.0.1:0"); err != nil {
+		t.Fatalf("функция Listen(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer func() { _ = ltn.Close() }()
+	if conn, err = net.Dial(netTcp, ltn.Addr().String()); err != nil {
+		t.Fatalf("функция Dial(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer func() { _ = conn.Close() }()
+	if _, err = ConnPeerCredentials(conn); !errors.Is(err, Errors().PeerCredentialsConn()) {
+		t.Errorf("функция ConnPeerCredentials(), ошибка: %v, ожидалось: %v", err, Errors().PeerCredentialsConn())
+	}
+	if _, err = ConnPeerCredentials(nil); !errors.Is(err, Errors().PeerCredentialsConn()) {
+		t.Errorf("функция ConnPeerCredentials(nil), ошибка: %v, ожидалось: %v", err, Errors().PeerCredentialsConn())
+	}
+}
+
+// Без списков разрешённых пользователей и групп слушатель не оборачивается.
+func TestPeerCredentialsListenerDisabled(t *testing.T) {
+	var ltn net.Listener = new(net.UnixListener)
+
+	if ret := peerCredentialsListener(&Configuration{}, ltn); ret != ltn {
+		t.Errorf("функция peerCredentialsListener(), вернулось: %T, ожидалось: %T", ret, ltn)
+	}
+	if ret := peerCredentialsListener(&Configuration{SocketPeerGID: []uint32{0}}, ltn); unwrapListener(ret) != ltn {
+		t.Errorf("функция unwrapListener(), вернулось: %T, ожидалось: %T", unwrapListener(ret), ltn)
+	}
+}
+
+// В операционных системах, не поддерживающих учётные данные процесса клиента, списки разрешённых пользователей и
+// групп являются ошибкой открытия слушателя, а не причиной закрытия всех соединений.
+func TestPeerCredentialsNotSupported(t *testing.T) {
+	var (
+		err    error
+		ltn    net.Listener
+		expect error
+	)
+
+	if !peerCredentialsSupported {
+		expect = Errors().SocketOptionNotSupported()
+	}
+	ltn, _, err = New().NewListener(&Configuration{Host: "127.0.0.1", SocketPeerUID: []uint32{0}})
+	if ltn != nil {
+		_ = ltn.Close()
+	}
+	if !errors.Is(err, expect) {
+		t.Errorf("функция NewListener(), ошибка: %v, ожидалось: %v", err, expect)
+	}
+}
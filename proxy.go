@@ -0,0 +1,295 @@
+package net
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pires/go-proxyproto"
+)
+
+// NewProxyHandler Создание основной функции TCP сервера, проксирующей каждое принятое соединение на один из
+// вышестоящих серверов. Данные копируются в обе стороны, завершение передачи одной из сторон передаётся другой
+// стороне через закрытие соединения на запись. При остановке сервера все проксируемые соединения закрываются.
+func NewProxyHandler(conf *ProxyConfiguration) (ret HandlerFn, err error) {
+	var (
+		prx *proxy
+		ups *proxyUpstream
+		n   int
+	)
+
+	if conf == nil {
+		err = Errors().NoConfiguration()
+		return
+	}
+	prx = &proxy{conf: new(ProxyConfiguration), lck: new(sync.Mutex)}
+	*prx.conf = *conf
+	defaultProxyConfiguration(prx.conf)
+	switch {
+	case len(prx.conf.Upstream) == 0:
+		err = Errors().ProxyUpstreamNotSet()
+		return
+	case prx.conf.Balance != ProxyBalanceRoundRobin && prx.conf.Balance != ProxyBalanceLeastConn:
+		err = fmt.Errorf("%w %q", Errors().ProxyBalance(), prx.conf.Balance)
+		return
+	case prx.conf.ProxyProtocol > 2:
+		err = Errors().ProxyProtocolVersion()
+		return
+	}
+	for n = range prx.conf.Upstream {
+		if ups, err = parseProxyUpstream(prx.conf.Upstream[n]); err != nil {
+			return
+		}
+		prx.upstreams = append(prx.upstreams, ups)
+	}
+	ret = prx.serve
+
+	return
+}
+
+// Наполнение конфигурации проксирования значениями по умолчанию.
+func defaultProxyConfiguration(conf *ProxyConfiguration) {
+	if conf.Balance = strings.ToLower(strings.TrimSpace(conf.Balance)); conf.Balance == "" {
+		conf.Balance = ProxyBalanceRoundRobin
+	}
+	if conf.ConnectTimeout <= 0 {
+		conf.ConnectTimeout = proxyDefaultConnectTimeout
+	}
+	if conf.MaxFails == 0 {
+		conf.MaxFails = proxyDefaultMaxFails
+	}
+	if conf.FailTimeout <= 0 {
+		conf.FailTimeout = proxyDefaultFailTimeout
+	}
+}
+
+// Разбор адреса вышестоящего сервера. Допускаются адреса TCP/IP без схемы или со схемой tcp, tcp4, tcp6 и адреса
+// unix сокетов, остальные схемы являются ошибкой.
+func parseProxyUpstream(addr string) (ret *proxyUpstream, err error) {
+	var conf *Configuration
+
+	if addr = strings.TrimSpace(addr); strings.HasPrefix(addr, "/") {
+		ret = &proxyUpstream{network: netUnix, address: addr}
+		return
+	}
+	switch addressScheme(addr) {
+	case "", netTcp, netTcp4, netTcp6, netUnix:
+	default:
+		err = fmt.Errorf("%w %q", Errors().ProxyUpstreamAddress(), addr)
+		return
+	}
+	if conf, err = parseAddress(addr, ""); err != nil {
+		err = fmt.Errorf("%w %q: %s", Errors().ProxyUpstreamAddress(), addr, err)
+		return
+	}
+	switch conf.Mode {
+	case netUnix:
+		ret = &proxyUpstream{network: netUnix, address: conf.Socket}
+	case netTcp, netTcp4, netTcp6:
+		if conf.Port == 0 {
+			err = fmt.Errorf("%w %q", Errors().ProxyUpstreamAddress(), addr)
+			return
+		}
+		ret = &proxyUpstream{network: conf.Mode, address: conf.HostPort()}
+	default:
+		err = fmt.Errorf("%w %q", Errors().ProxyUpstreamAddress(), addr)
+	}
+
+	return
+}
+
+// Основная функция TCP сервера, приём соединений и запуск проксирования. Функция завершается при закрытии
+// слушателя или при ошибке приёма соединения, не связанной с нехваткой ресурсов.
+func (prx *proxy) serve(ltn net.Listener) (err error) {
+	var (
+		wg       *sync.WaitGroup
+		lck      *sync.Mutex
+		sessions map[net.Conn]struct{}
+		conn     net.Conn
+		ne       net.Error
+		delay    time.Duration
+		retry    bool
+	)
+
+	wg, lck, sessions = new(sync.WaitGroup), new(sync.Mutex), make(map[net.Conn]struct{})
+	defer func() {
+		lck.Lock()
+		for conn = range sessions {
+			_ = conn.Close()
+		}
+		lck.Unlock()
+		wg.Wait()
+	}()
+	for {
+		if conn, err = ltn.Accept(); err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				err = nil
+				return
+			}
+			if errors.As(err, &ne) && ne.Timeout() {
+				continue
+			}
+			if delay, retry = acceptRetryDelay(err, delay); !retry {
+				return
+			}
+			time.Sleep(delay)
+			continue
+		}
+		delay = 0
+		lck.Lock()
+		sessions[conn] = struct{}{}
+		lck.Unlock()
+		wg.Add(1)
+		go func(c net.Conn) {
+			defer wg.Done()
+			prx.session(c)
+			lck.Lock()
+			delete(sessions, c)
+			lck.Unlock()
+		}(conn)
+	}
+}
+
+// Проксирование одного соединения.
+func (prx *proxy) session(client net.Conn) {
+	var (
+		err      error
+		ups      *proxyUpstream
+		upstream net.Conn
+	)
+
+	defer func() { _ = client.Close() }()
+	if ups, upstream, err = prx.dial(); err != nil {
+		return
+	}
+	defer prx.release(ups)
+	defer func() { _ = upstream.Close() }()
+	if prx.conf.ProxyProtocol > 0 {
+		if _, err = proxyproto.
+			HeaderProxyFromAddrs(prx.conf.ProxyProtocol, client.RemoteAddr(), client.LocalAddr()).
+			WriteTo(upstream); err != nil {
+			return
+		}
+	}
+	proxyCopy(client, upstream)
+}
+
+// Соединение с вышестоящим сервером, при неудаче выполняется попытка соединения со следующим сервером.
+func (prx *proxy) dial() (ups *proxyUpstream, conn net.Conn, err error) {
+	var (
+		dialer *net.Dialer
+		tried  []bool
+	)
+
+	dialer, tried = &net.Dialer{Timeout: prx.conf.ConnectTimeout}, make([]bool, len(prx.upstreams))
+	for range prx.upstreams {
+		if ups = prx.pick(tried); ups == nil {
+			break
+		}
+		if conn, err = dialer.Dial(ups.network, ups.address); err == nil {
+			prx.result(ups, true)
+			return
+		}
+		prx.result(ups, false)
+	}
+	ups, err = nil, Errors().ProxyUpstreamUnavailable()
+
+	return
+}
+
+// Выбор вышестоящего сервера согласно алгоритму балансировки.
+// Недоступные серверы выбираются только если не осталось доступных серверов, с которыми не было попытки
+// соединения.
+func (prx *proxy) pick(tried []bool) (ret *proxyUpstream) {
+	var (
+		now   time.Time
+		ups   *proxyUpstream
+		n, m  int
+		found int
+		down  bool
+	)
+
+	prx.lck.Lock()
+	defer prx.lck.Unlock()
+	now, found = time.Now(), -1
+	for _, down = range []bool{false, true} {
+		for n = 0; n < len(prx.upstreams); n++ {
+			m = (prx.next + n) % len(prx.upstreams)
+			if ups = prx.upstreams[m]; tried[m] || !down && now.Before(ups.downTo) {
+				continue
+			}
+			if found < 0 || prx.conf.Balance == ProxyBalanceLeastConn && ups.active < prx.upstreams[found].active {
+				found = m
+			}
+			if prx.conf.Balance == ProxyBalanceRoundRobin {
+				break
+			}
+		}
+		if found >= 0 {
+			break
+		}
+	}
+	if found < 0 {
+		return
+	}
+	tried[found], prx.next = true, (found+1)%len(prx.upstreams)
+	ret = prx.upstreams[found]
+	ret.active++
+
+	return
+}
+
+// Учёт результата соединения с вышестоящим сервером, пассивная проверка доступности.
+func (prx *proxy) result(ups *proxyUpstream, ok bool) {
+	prx.lck.Lock()
+	defer prx.lck.Unlock()
+	if ok {
+		ups.fails, ups.downTo = 0, time.Time{}
+		return
+	}
+	ups.active--
+	if ups.fails++; ups.fails >= prx.conf.MaxFails {
+		ups.downTo = time.Now().Add(prx.conf.FailTimeout)
+	}
+}
+
+// Освобождение вышестоящего сервера после завершения проксируемого соединения.
+func (prx *proxy) release(ups *proxyUpstream) {
+	prx.lck.Lock()
+	defer prx.lck.Unlock()
+	ups.active--
+}
+
+// Копирование данных между соединениями в обе стороны до завершения передачи обеими сторонами.
+func proxyCopy(a net.Conn, b net.Conn) {
+	var wg = new(sync.WaitGroup)
+
+	wg.Add(2)
+	go func() { defer wg.Done(); proxyCopyHalf(b, a) }()
+	go func() { defer wg.Done(); proxyCopyHalf(a, b) }()
+	wg.Wait()
+}
+
+// Копирование данных в одну сторону, по завершении соединение получателя закрывается на запись.
+// Если соединение не поддерживает закрытие на запись, соединение закрывается полностью.
+func proxyCopyHalf(dst net.Conn, src net.Conn) {
+	if _, err := io.Copy(dst, src); err != nil || !closeWrite(dst) {
+		_ = dst.Close()
+		_ = src.Close()
+	}
+}
+
+// Закрытие соединения на запись, возвращается ложь, если соединение не поддерживает закрытие на запись.
+func closeWrite(conn net.Conn) bool {
+	for ; conn != nil; conn = unwrapConn(conn) {
+		if cw, ok := conn.(interface{ CloseWrite() error }); ok {
+			return cw.CloseWrite() == nil
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,272 @@
+package net
+
+import (
+	"errors"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/pires/go-proxyproto"
+)
+
+// Вышестоящий сервер для тестирования, отвечающий своим именем, адресом клиента и полученными данными после
+// закрытия клиентом соединения на запись.
+func testProxyUpstream(t *testing.T, name string, withProxyProtocol bool) (ret net.Listener) {
+	var err error
+
+	if ret, err = net.Listen("tcp", "127.0.0.1:0"); err != nil {
+		t.Fatalf("функция Listen(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	if withProxyProtocol {
+		ret = &proxyproto.Listener{Listener: ret}
+	}
+	go func(ltn net.Listener) {
+		for {
+			conn, e := ltn.Accept()
+			if e != nil {
+				return
+			}
+			go func(c net.Conn) {
+				defer func() { _ = c.Close() }()
+				data, _ := io.ReadAll(c)
+				_, _ = c.Write([]byte(name + " " + c.RemoteAddr().String() + " " + string(data)))
+			}(conn)
+		}
+	}(ret)
+
+	return
+}
+
+// Запрос через проксирующий сервер, с закрытием соединения на запись после отправки данных.
+func testProxyRequest(t *testing.T, addr string, data string) (ret string, local string) {
+	var (
+		err  error
+		conn net.Conn
+		buf  []byte
+	)
+
+	if conn, err = net.Dial("tcp", addr); err != nil {
+		t.Fatalf("функция Dial(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer func() { _ = conn.Close() }()
+	if _, err = conn.Write([]byte(data)); err != nil {
+		t.Fatalf("функция Write(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	_ = conn.(*net.TCPConn).CloseWrite()
+	if buf, err = io.ReadAll(conn); err != nil {
+		t.Fatalf("функция ReadAll(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	ret, local = string(buf), conn.LocalAddr().String()
+
+	return
+}
+
+func TestNewProxyHandler(t *testing.T) {
+	var tests = []struct {
+		conf *ProxyConfiguration
+		err  error
+	}{
+		{nil, Errors().NoConfiguration()},
+		{&ProxyConfiguration{}, Errors().ProxyUpstreamNotSet()},
+		{&ProxyConfiguration{Upstream: []string{"127.0.0.1:1"}, Balance: "random"}, Errors().ProxyBalance()},
+		{&ProxyConfiguration{Upstream: []string{"127.0.0.1:1"}, ProxyProtocol: 3}, Errors().ProxyProtocolVersion()},
+		{&ProxyConfiguration{Upstream: []string{"127.0.0.1"}}, Errors().ProxyUpstreamAddress()},
+		{&ProxyConfiguration{Upstream: []string{"udp://127.0.0.1:1"}}, Errors().ProxyUpstreamAddress()},
+		{&ProxyConfiguration{Upstream: []string{"systemd://proxy"}}, Errors().ProxyUpstreamAddress()},
+		{&ProxyConfiguration{Upstream: []string{"unixpacket:/tmp/a.sock"}}, Errors().ProxyUpstreamAddress()},
+		{&ProxyConfiguration{Upstream: []string{"127.0.0.1:1", "unix:/tmp/a.sock", "/tmp/b.sock"}}, nil},
+	}
+
+	for n := range tests {
+		if _, err := NewProxyHandler(tests[n].conf); !errors.Is(err, tests[n].err) {
+			t.Errorf("функция NewProxyHandler(), ошибка: %v, ожидалось: %v", err, tests[n].err)
+		}
+	}
+}
+
+func TestParseProxyUpstream(t *testing.T) {
+	var tests = []struct {
+		addr    string
+		network string
+		address string
+	}{
+		{"127.0.0.1:80", "tcp", "127.0.0.1:80"},
+		{"tcp://127.0.0.1:http", "tcp", "127.0.0.1:80"},
+		{"tcp4://127.0.0.1:80", "tcp4", "127.0.0.1:80"},
+		{"tcp6://[::1]:80", "tcp6", "[::1]:80"},
+		{"/run/a.sock", "unix", "/run/a.sock"},
+		{"unix:/run/a.sock", "unix", "/run/a.sock"},
+		{"unix:///run/a.sock", "unix", "/run/a.sock"},
+		{"unix:/run/a.sock?mode=0660", "unix", "/run/a.sock"},
+	}
+
+	for n := range tests {
+		ups, err := parseProxyUpstream(tests[n].addr)
+		if err != nil {
+			t.Errorf("функция parseProxyUpstream(%q), ошибка: %v, ожидалось: %v", tests[n].addr, err, nil)
+			continue
+		}
+		if ups.network != tests[n].network || ups.address != tests[n].address {
+			t.Errorf("функция parseProxyUpstream(%q), вернулось: %q %q, ожидалось: %q %q",
+				tests[n].addr, ups.network, ups.address, tests[n].network, tests[n].address)
+		}
+	}
+}
+
+func TestProxyRoundRobin(t *testing.T) {
+	var (
+		err      error
+		upstream [2]net.Listener
+		dead     net.Listener
+		handler  HandlerFn
+		nut      Interface
+		addr     string
+		ret      string
+	)
+
+	upstream[0], upstream[1] = testProxyUpstream(t, "a", false), testProxyUpstream(t, "b", true)
+	defer func() { _ = upstream[0].Close(); _ = upstream[1].Close() }()
+	// Адрес, на котором никто не принимает соединения.
+	dead = testProxyUpstream(t, "-", false)
+	_ = dead.Close()
+	if handler, err = NewProxyHandler(&ProxyConfiguration{
+		Upstream: []string{upstream[0].Addr().String(), dead.Addr().String(), upstream[1].Addr().String()},
+	}); err != nil {
+		t.Fatalf("функция NewProxyHandler(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	nut = New().Handler(handler)
+	if err = nut.ListenAndServe("127.0.0.1:0").Error(); err != nil {
+		t.Fatalf("функция ListenAndServe(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer nut.Stop()
+	addr = nut.(*impl).listener.Addr().String()
+	for _, name := range []string{"a", "b", "a", "b"} {
+		ret, _ = testProxyRequest(t, addr, "ping")
+		if ret[:1] != name || ret[len(ret)-4:] != "ping" {
+			t.Errorf("проксирование, ответ: %q, ожидался ответ сервера %q", ret, name)
+		}
+	}
+}
+
+func TestProxyProtocolHeader(t *testing.T) {
+	var (
+		err      error
+		upstream net.Listener
+		handler  HandlerFn
+		nut      Interface
+		ret      string
+		local    string
+	)
+
+	upstream = testProxyUpstream(t, "a", true)
+	defer func() { _ = upstream.Close() }()
+	if handler, err = NewProxyHandler(&ProxyConfiguration{
+		Upstream:      []string{upstream.Addr().String()},
+		ProxyProtocol: 1,
+	}); err != nil {
+		t.Fatalf("функция NewProxyHandler(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	nut = New().Handler(handler)
+	if err = nut.ListenAndServe("127.0.0.1:0").Error(); err != nil {
+		t.Fatalf("функция ListenAndServe(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer nut.Stop()
+	// Вышестоящий сервер видит адрес клиента проксирующего сервера.
+	if ret, local = testProxyRequest(t, nut.(*impl).listener.Addr().String(), "ping"); ret != "a "+local+" ping" {
+		t.Errorf("проксирование, ответ: %q, ожидалось: %q", ret, "a "+local+" ping")
+	}
+}
+
+func TestProxyLeastConn(t *testing.T) {
+	var (
+		prx *proxy
+		ups *proxyUpstream
+	)
+
+	prx = &proxy{
+		conf:      &ProxyConfiguration{Balance: ProxyBalanceLeastConn, MaxFails: 1, FailTimeout: time.Minute},
+		upstreams: []*proxyUpstream{{active: 3}, {active: 1}, {active: 2}},
+		lck:       new(sync.Mutex),
+	}
+	if ups = prx.pick(make([]bool, 3)); ups != prx.upstreams[1] || ups.active != 2 {
+		t.Errorf("функция pick(), выбран сервер с %d соединениями, ожидалось: %d", ups.active, 2)
+	}
+	// Недоступный сервер выбирается, только если все остальные серверы уже были испробованы.
+	prx.result(prx.upstreams[1], false)
+	if ups = prx.pick(make([]bool, 3)); ups != prx.upstreams[2] {
+		t.Errorf("функция pick(), выбран недоступный сервер")
+	}
+	if ups = prx.pick([]bool{true, false, true}); ups != prx.upstreams[1] {
+		t.Errorf("функция pick(), не выбран последний не испробованный сервер")
+	}
+}
+
+// Слушатель, возвращающий ошибки приёма соединения до передачи соединений исходного слушателя.
+type testProxyFailingListener struct {
+	net.Listener
+	fails int
+	errno syscall.Errno
+}
+
+// Accept Ошибка приёма соединения, затем соединение исходного слушателя.
+func (fln *testProxyFailingListener) Accept() (net.Conn, error) {
+	if fln.fails > 0 {
+		fln.fails--
+		return nil, &net.OpError{Op: "accept", Net: "tcp", Err: os.NewSyscallError("accept", fln.errno)}
+	}
+
+	return fln.Listener.Accept()
+}
+
+// Ошибки нехватки ресурсов при приёме соединения не завершают проксирование, приём соединений повторяется.
+func TestProxyAcceptRetry(t *testing.T) {
+	var (
+		err      error
+		upstream net.Listener
+		ltn      net.Listener
+		handler  HandlerFn
+		done     chan error
+		ret      string
+	)
+
+	upstream = testProxyUpstream(t, "a", false)
+	defer func() { _ = upstream.Close() }()
+	if handler, err = NewProxyHandler(&ProxyConfiguration{Upstream: []string{upstream.Addr().String()}}); err != nil {
+		t.Fatalf("функция NewProxyHandler(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	if ltn, err = net.Listen("tcp", "127.0.0.1:0"); err != nil {
+		t.Fatalf("функция Listen(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	done = make(chan error, 1)
+	go func() { done <- handler(&testProxyFailingListener{Listener: ltn, fails: 3, errno: syscall.EMFILE}) }()
+	if ret, _ = testProxyRequest(t, ltn.Addr().String(), "ping"); ret[:1] != "a" {
+		t.Errorf("проксирование, ответ: %q, ожидался ответ сервера %q", ret, "a")
+	}
+	_ = ltn.Close()
+	select {
+	case err = <-done:
+		if err != nil {
+			t.Errorf("функция проксирования, ошибка: %v, ожидалось: %v", err, nil)
+		}
+	case <-time.After(time.Second * 2):
+		t.Errorf("функция проксирования не завершена после закрытия слушателя")
+	}
+	// Ошибка, не связанная с нехваткой ресурсов, завершает проксирование.
+	if ltn, err = net.Listen("tcp", "127.0.0.1:0"); err != nil {
+		t.Fatalf("функция Listen(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer func() { _ = ltn.Close() }()
+	go func() { done <- handler(&testProxyFailingListener{Listener: ltn, fails: 1, errno: syscall.EINVAL}) }()
+	select {
+	case err = <-done:
+		if !errors.Is(err, syscall.EINVAL) {
+			t.Errorf("функция проксирования, ошибка: %v, ожидалось: %v", err, syscall.EINVAL)
+		}
+	case <-time.After(time.Second * 2):
+		t.Errorf("функция проксирования не завершена после ошибки приёма соединения")
+	}
+}
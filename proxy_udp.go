@@ -0,0 +1,260 @@
+package net
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// NewUdpProxy Создание проксирования UDP пакетов с отслеживанием сессий клиентов.
+// Основная функция UDP сервера, возвращаемая Handler(), назначается серверу через HandlerUdp().
+func NewUdpProxy(conf *UdpProxyConfiguration) (ret UdpProxy, err error) {
+	var (
+		upx      *udpProxy
+		addr     string
+		upstream *net.UDPAddr
+		n        int
+	)
+
+	if conf == nil {
+		err = Errors().NoConfiguration()
+		return
+	}
+	upx = &udpProxy{
+		conf:     new(UdpProxyConfiguration),
+		next:     new(atomic.Uint64),
+		lck:      new(sync.Mutex),
+		sessions: make(map[string]*udpSession),
+		metrics:  new(udpProxyMetrics),
+	}
+	*upx.conf = *conf
+	defaultUdpProxyConfiguration(upx.conf)
+	if len(upx.conf.Upstream) == 0 {
+		err = Errors().ProxyUpstreamNotSet()
+		return
+	}
+	for n = range upx.conf.Upstream {
+		addr = strings.TrimSpace(upx.conf.Upstream[n])
+		if upstream, err = net.ResolveUDPAddr(netUdp, addr); err != nil {
+			err = fmt.Errorf("%w %q: %s", Errors().ProxyUpstreamAddress(), addr, err)
+			return
+		}
+		upx.upstreams = append(upx.upstreams, upstream)
+	}
+	ret = upx
+
+	return
+}
+
+// Наполнение конфигурации проксирования UDP значениями по умолчанию.
+func defaultUdpProxyConfiguration(conf *UdpProxyConfiguration) {
+	if conf.IdleTimeout <= 0 {
+		conf.IdleTimeout = udpProxyDefaultIdleTimeout
+	}
+	if conf.BufferSize == 0 {
+		conf.BufferSize = udpProxyDefaultBufferSize
+	}
+}
+
+// Handler Основная функция UDP сервера.
+func (upx *udpProxy) Handler() HandlerUdpFn { return upx.serve }
+
+// Metrics Счётчики проксирования.
+func (upx *udpProxy) Metrics() UdpProxyMetrics {
+	return UdpProxyMetrics{
+		Sessions:         uint64(upx.metrics.sessions.Load()),
+		SessionsTotal:    upx.metrics.sessionsTotal.Load(),
+		SessionsExpired:  upx.metrics.sessionsExpired.Load(),
+		SessionsRejected: upx.metrics.sessionsRejected.Load(),
+		PacketsIn:        upx.metrics.packetsIn.Load(),
+		PacketsOut:       upx.metrics.packetsOut.Load(),
+		BytesIn:          upx.metrics.bytesIn.Load(),
+		BytesOut:         upx.metrics.bytesOut.Load(),
+		Errors:           upx.metrics.errors.Load(),
+	}
+}
+
+// Приём пакетов клиентов и передача пакетов вышестоящим серверам.
+func (upx *udpProxy) serve(pc net.PacketConn) (err error) {
+	var (
+		wg   *sync.WaitGroup
+		buf  []byte
+		n    int
+		addr net.Addr
+		ses  *udpSession
+		ne   net.Error
+	)
+
+	wg, buf = new(sync.WaitGroup), make([]byte, upx.conf.BufferSize)
+	defer func() {
+		upx.lck.Lock()
+		for _, ses = range upx.sessions {
+			_ = ses.upstream.Close()
+		}
+		upx.lck.Unlock()
+		wg.Wait()
+	}()
+	for {
+		if n, addr, err = pc.ReadFrom(buf); err != nil {
+			if errors.As(err, &ne) && ne.Timeout() {
+				continue
+			}
+			if errors.Is(err, net.ErrClosed) {
+				err = nil
+			}
+			return
+		}
+		upx.forward(pc, wg, addr, buf[:n])
+	}
+}
+
+// Передача пакета клиента вышестоящему серверу через сессию клиента. Если запись в сокет сессии завершилась
+// ошибкой, например, сессия была завершена по времени бездействия после её получения, сессия завершается и пакет
+// передаётся через новую сессию.
+func (upx *udpProxy) forward(pc net.PacketConn, wg *sync.WaitGroup, addr net.Addr, data []byte) {
+	const attempts = 2
+	var (
+		err error
+		ses *udpSession
+		n   int
+	)
+
+	for n = 0; n < attempts; n++ {
+		if ses = upx.session(addr); ses == nil {
+			return
+		}
+		if ses.upstream == nil {
+			if !upx.open(ses) {
+				return
+			}
+			wg.Add(1)
+			go func(s *udpSession) { defer wg.Done(); upx.reply(pc, s) }(ses)
+		}
+		ses.last.Store(time.Now().UnixNano())
+		if _, err = ses.upstream.Write(data); err == nil {
+			upx.metrics.packetsIn.Add(1)
+			upx.metrics.bytesIn.Add(uint64(len(data)))
+			return
+		}
+		upx.lck.Lock()
+		upx.drop(ses)
+		upx.lck.Unlock()
+		_ = ses.upstream.Close()
+	}
+	upx.metrics.errors.Add(1)
+}
+
+// Поиск сессии клиента, создание новой сессии с проверкой лимита сессий.
+// Возвращается nil, если лимит сессий исчерпан. Для новой сессии сокет к вышестоящему серверу не открыт.
+func (upx *udpProxy) session(addr net.Addr) (ret *udpSession) {
+	var ok bool
+
+	upx.lck.Lock()
+	defer upx.lck.Unlock()
+	if ret, ok = upx.sessions[addr.String()]; ok {
+		return
+	}
+	if upx.conf.MaxSessions > 0 && uint(len(upx.sessions)) >= upx.conf.MaxSessions {
+		upx.metrics.sessionsRejected.Add(1)
+		return
+	}
+	ret = &udpSession{client: addr, last: new(atomic.Int64)}
+	ret.last.Store(time.Now().UnixNano())
+
+	return
+}
+
+// Открытие сокета к вышестоящему серверу и регистрация сессии.
+func (upx *udpProxy) open(ses *udpSession) bool {
+	var (
+		err      error
+		upstream *net.UDPAddr
+	)
+
+	upstream = upx.upstreams[(upx.next.Add(1)-1)%uint64(len(upx.upstreams))]
+	if ses.upstream, err = net.DialUDP(netUdp, nil, upstream); err != nil {
+		upx.metrics.errors.Add(1)
+		return false
+	}
+	upx.lck.Lock()
+	upx.sessions[ses.client.String()] = ses
+	upx.lck.Unlock()
+	upx.metrics.sessions.Add(1)
+	upx.metrics.sessionsTotal.Add(1)
+
+	return true
+}
+
+// Передача ответов вышестоящего сервера клиенту до завершения сессии по времени бездействия.
+func (upx *udpProxy) reply(pc net.PacketConn, ses *udpSession) {
+	var (
+		err  error
+		buf  []byte
+		n    int
+		last time.Time
+		ne   net.Error
+	)
+
+	defer func() {
+		upx.lck.Lock()
+		upx.drop(ses)
+		upx.lck.Unlock()
+		upx.metrics.sessions.Add(-1)
+		_ = ses.upstream.Close()
+	}()
+	buf = make([]byte, upx.conf.BufferSize)
+	for {
+		last = time.Unix(0, ses.last.Load())
+		_ = ses.upstream.SetReadDeadline(last.Add(upx.conf.IdleTimeout))
+		if n, err = ses.upstream.Read(buf); err != nil {
+			switch {
+			case errors.As(err, &ne) && ne.Timeout():
+				if upx.expire(ses) {
+					upx.metrics.sessionsExpired.Add(1)
+					return
+				}
+				continue
+			case errors.Is(err, net.ErrClosed):
+				return
+			}
+			// Ошибки вышестоящего сервера, например, ICMP port unreachable, не завершают сессию.
+			upx.metrics.errors.Add(1)
+			continue
+		}
+		ses.last.Store(time.Now().UnixNano())
+		if _, err = pc.WriteTo(buf[:n], ses.client); err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			upx.metrics.errors.Add(1)
+			continue
+		}
+		upx.metrics.packetsOut.Add(1)
+		upx.metrics.bytesOut.Add(uint64(n))
+	}
+}
+
+// Завершение сессии по времени бездействия. Проверка времени и удаление сессии из списка сессий выполняются под
+// блокировкой, поэтому после завершения сессии новые пакеты клиента передаются через новую сессию.
+func (upx *udpProxy) expire(ses *udpSession) bool {
+	upx.lck.Lock()
+	defer upx.lck.Unlock()
+	if time.Since(time.Unix(0, ses.last.Load())) < upx.conf.IdleTimeout {
+		return false
+	}
+	upx.drop(ses)
+
+	return true
+}
+
+// Завершение сессии и удаление сессии из списка сессий, функция вызывается под блокировкой upx.lck.
+// Сессия удаляется из списка, только если клиенту не назначена новая сессия.
+func (upx *udpProxy) drop(ses *udpSession) {
+	if upx.sessions[ses.client.String()] == ses {
+		delete(upx.sessions, ses.client.String())
+	}
+}
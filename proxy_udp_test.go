@@ -0,0 +1,181 @@
+package net
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Вышестоящий UDP сервер для тестирования, отвечающий своим именем и полученными данными.
+func testUdpProxyUpstream(t *testing.T, name string) (ret net.PacketConn) {
+	var err error
+
+	if ret, err = net.ListenPacket("udp", "127.0.0.1:0"); err != nil {
+		t.Fatalf("функция ListenPacket(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	go func(pc net.PacketConn) {
+		var buf = make([]byte, 1024)
+		for {
+			n, addr, e := pc.ReadFrom(buf)
+			if e != nil {
+				return
+			}
+			_, _ = pc.WriteTo(append([]byte(name+" "), buf[:n]...), addr)
+		}
+	}(ret)
+
+	return
+}
+
+// Отправка пакета через проксирующий сервер и получение ответа.
+func testUdpProxyRequest(t *testing.T, conn net.Conn, data string) (ret string) {
+	var (
+		err error
+		buf []byte
+		n   int
+	)
+
+	if _, err = conn.Write([]byte(data)); err != nil {
+		t.Fatalf("функция Write(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	buf = make([]byte, 1024)
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+	if n, err = conn.Read(buf); err != nil {
+		t.Fatalf("функция Read(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	ret = string(buf[:n])
+
+	return
+}
+
+func TestNewUdpProxy(t *testing.T) {
+	var tests = []struct {
+		conf *UdpProxyConfiguration
+		err  error
+	}{
+		{nil, Errors().NoConfiguration()},
+		{&UdpProxyConfiguration{}, Errors().ProxyUpstreamNotSet()},
+		{&UdpProxyConfiguration{Upstream: []string{"127.0.0.1"}}, Errors().ProxyUpstreamAddress()},
+		{&UdpProxyConfiguration{Upstream: []string{"127.0.0.1:port"}}, Errors().ProxyUpstreamAddress()},
+		{&UdpProxyConfiguration{Upstream: []string{"127.0.0.1:53"}}, nil},
+	}
+
+	for n := range tests {
+		if _, err := NewUdpProxy(tests[n].conf); !errors.Is(err, tests[n].err) {
+			t.Errorf("функция NewUdpProxy(), ошибка: %v, ожидалось: %v", err, tests[n].err)
+		}
+	}
+}
+
+func TestUdpProxy(t *testing.T) {
+	const idle = time.Millisecond * 100
+	var (
+		err      error
+		upstream [2]net.PacketConn
+		upx      UdpProxy
+		lpc      net.PacketConn
+		nut      Interface
+		client   [3]net.Conn
+		metrics  UdpProxyMetrics
+	)
+
+	upstream[0], upstream[1] = testUdpProxyUpstream(t, "a"), testUdpProxyUpstream(t, "b")
+	defer func() { _ = upstream[0].Close(); _ = upstream[1].Close() }()
+	if upx, err = NewUdpProxy(&UdpProxyConfiguration{
+		Upstream:    []string{upstream[0].LocalAddr().String(), upstream[1].LocalAddr().String()},
+		IdleTimeout: idle,
+		MaxSessions: 2,
+	}); err != nil {
+		t.Fatalf("функция NewUdpProxy(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	if lpc, err = net.ListenPacket("udp", "127.0.0.1:0"); err != nil {
+		t.Fatalf("функция ListenPacket(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	nut = New().HandlerUdp(upx.Handler())
+	if err = nut.ServeUdp(lpc).Error(); err != nil {
+		t.Fatalf("функция ServeUdp(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer nut.Stop()
+	for n := range client {
+		if client[n], err = net.Dial("udp", lpc.LocalAddr().String()); err != nil {
+			t.Fatalf("функция Dial(), ошибка: %v, ожидалось: %v", err, nil)
+		}
+		defer func(c net.Conn) { _ = c.Close() }(client[n])
+	}
+	// Пакеты одного клиента передаются в одну сессию, разные клиенты распределяются по вышестоящим серверам.
+	for _, test := range []struct {
+		client int
+		ret    string
+	}{{0, "a 1"}, {0, "a 1"}, {1, "b 1"}} {
+		if ret := testUdpProxyRequest(t, client[test.client], "1"); ret != test.ret {
+			t.Errorf("проксирование, ответ: %q, ожидалось: %q", ret, test.ret)
+		}
+	}
+	// Пакет третьего клиента отбрасывается из-за лимита сессий.
+	_, _ = client[2].Write([]byte("1"))
+	time.Sleep(idle * 3)
+	metrics = upx.Metrics()
+	if metrics.Sessions != 0 || metrics.SessionsTotal != 2 || metrics.SessionsExpired != 2 ||
+		metrics.SessionsRejected != 1 || metrics.PacketsIn != 3 || metrics.PacketsOut != 3 || metrics.BytesOut != 9 {
+		t.Errorf("функция Metrics(), вернулось: %+v", metrics)
+	}
+	// После завершения сессий по времени бездействия лимит сессий освобождается.
+	if ret := testUdpProxyRequest(t, client[2], "2"); ret != "a 2" {
+		t.Errorf("проксирование, ответ: %q, ожидалось: %q", ret, "a 2")
+	}
+}
+
+// Пакет, полученный для завершённой сессии, передаётся через новую сессию.
+func TestUdpProxyClosedSession(t *testing.T) {
+	var (
+		err      error
+		upstream net.PacketConn
+		upx      UdpProxy
+		pc       net.PacketConn
+		client   net.PacketConn
+		ses      *udpSession
+		wg       *sync.WaitGroup
+		buf      []byte
+		n        int
+	)
+
+	upstream = testUdpProxyUpstream(t, "a")
+	defer func() { _ = upstream.Close() }()
+	if upx, err = NewUdpProxy(&UdpProxyConfiguration{Upstream: []string{upstream.LocalAddr().String()}}); err != nil {
+		t.Fatalf("функция NewUdpProxy(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	if pc, err = net.ListenPacket("udp", "127.0.0.1:0"); err != nil {
+		t.Fatalf("функция ListenPacket(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	if client, err = net.ListenPacket("udp", "127.0.0.1:0"); err != nil {
+		t.Fatalf("функция ListenPacket(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer func() { _ = client.Close() }()
+	// Сессия, сокет которой закрыт при завершении по времени бездействия.
+	ses = &udpSession{client: client.LocalAddr(), last: new(atomic.Int64)}
+	if ses.upstream, err = net.DialUDP("udp", nil, upstream.LocalAddr().(*net.UDPAddr)); err != nil {
+		t.Fatalf("функция DialUDP(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	_ = ses.upstream.Close()
+	upx.(*udpProxy).sessions[client.LocalAddr().String()] = ses
+	wg = new(sync.WaitGroup)
+	upx.(*udpProxy).forward(pc, wg, client.LocalAddr(), []byte("1"))
+	buf = make([]byte, 1024)
+	_ = client.SetReadDeadline(time.Now().Add(time.Second))
+	if n, _, err = client.ReadFrom(buf); err != nil || string(buf[:n]) != "a 1" {
+		t.Errorf("проксирование, ответ: %q, ошибка: %v, ожидалось: %q", buf[:n], err, "a 1")
+	}
+	if metrics := upx.Metrics(); metrics.PacketsIn != 1 || metrics.Errors != 0 {
+		t.Errorf("функция Metrics(), вернулось: %+v", metrics)
+	}
+	_ = pc.Close()
+	upx.(*udpProxy).lck.Lock()
+	for _, ses = range upx.(*udpProxy).sessions {
+		_ = ses.upstream.Close()
+	}
+	upx.(*udpProxy).lck.Unlock()
+	wg.Wait()
+}
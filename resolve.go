@@ -0,0 +1,207 @@
+package net
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Разрешение имени хоста конфигурации сервера в список IP адресов для открытия порта, согласно политике
+// HostResolve и режиму Mode. Если Host пустой или является IP адресом, разрешение имени не выполняется, для IP адреса
+// проверяется соответствие семейства адреса политике и режиму.
+// Если Host является именем сетевого интерфейса, используются текущие адреса интерфейса.
+func resolveHost(conf *Configuration) (ret []string, err error) {
+	var (
+		host    string
+		policy  string
+		network string
+		ips     []net.IPAddr
+		found   []string
+		seen    map[string]bool
+		n       int
+	)
+
+	switch policy = strings.ToLower(conf.HostResolve); policy {
+	case "", HostResolveFirst, HostResolveAll, HostResolveIPv4, HostResolveIPv6, HostResolveSingle:
+	default:
+		err = fmt.Errorf("%w %q", Errors().HostResolvePolicy(), conf.HostResolve)
+		return
+	}
+	switch conf.Mode {
+	case netTcp4, netUdp4:
+		network = "ip4"
+	case netTcp6, netUdp6:
+		network = "ip6"
+	default:
+		network = "ip"
+	}
+	if host = strings.TrimSuffix(strings.TrimPrefix(conf.Host, "["), "]"); host == "" {
+		ret = []string{host}
+		return
+	}
+	if isIPHost(host) {
+		if ip, _, _ := strings.Cut(host, "%"); !isFamilyAllowed(net.ParseIP(ip), network, policy) {
+			err = fmt.Errorf(
+				"%w %q, политика %q, режим %q", Errors().HostResolveNoAddress(), host, conf.HostResolve, conf.Mode,
+			)
+			return
+		}
+		ret = []string{host}
+		return
+	}
+	switch iface, e := net.InterfaceByName(host); e {
+	case nil:
+		if ips, err = interfaceAddrs(iface); err != nil {
+			err = fmt.Errorf("%w, сетевой интерфейс %q: %s", Errors().HostResolve(), host, err)
+			return
+		}
+	default:
+		if ips, err = net.DefaultResolver.LookupIPAddr(context.Background(), host); err != nil {
+			err = fmt.Errorf("%w %q: %s", Errors().HostResolve(), host, err)
+			return
+		}
+	}
+	seen = make(map[string]bool)
+	for n = range ips {
+		if !isFamilyAllowed(ips[n].IP, network, "") {
+			continue
+		}
+		if seen[ips[n].String()] {
+			continue
+		}
+		seen[ips[n].String()] = true
+		found = append(found, ips[n].String())
+	}
+	switch policy {
+	case "", HostResolveFirst:
+		ret = firstHost(found, func(net.IP) bool { return true })
+	case HostResolveAll:
+		ret = found
+	case HostResolveIPv4:
+		ret = firstHost(found, func(ip net.IP) bool { return ip.To4() != nil })
+	case HostResolveIPv6:
+		ret = firstHost(found, func(ip net.IP) bool { return ip.To4() == nil })
+	case HostResolveSingle:
+		if ret = found; len(found) > 1 {
+			err = fmt.Errorf("%w %q: %s", Errors().HostResolveAmbiguous(), host, strings.Join(found, ", "))
+			return
+		}
+	}
+	if len(ret) == 0 {
+		err = fmt.Errorf("%w %q, политика %q", Errors().HostResolveNoAddress(), host, conf.HostResolve)
+	}
+
+	return
+}
+
+// Возвращается истина, если семейство IP адреса допускается сетью режима (ip, ip4, ip6) и политикой HostResolve.
+func isFamilyAllowed(ip net.IP, network string, policy string) bool {
+	var isIPv4 = ip.To4() != nil
+
+	switch {
+	case network == "ip4" && !isIPv4, network == "ip6" && isIPv4:
+		return false
+	case policy == HostResolveIPv4 && !isIPv4, policy == HostResolveIPv6 && isIPv4:
+		return false
+	}
+
+	return true
+}
+
+// Возвращается истина, если хост указан как IP адрес, в том числе IPv6 адрес с зоной.
+func isIPHost(host string) bool {
+	if n := strings.IndexByte(host, '%'); n >= 0 {
+		host = host[:n]
+	}
+
+	return net.ParseIP(host) != nil
+}
+
+// Первый адрес списка, удовлетворяющий условию. Адреса списка могут содержать зону IPv6.
+func firstHost(hosts []string, fn func(ip net.IP) bool) (ret []string) {
+	var (
+		ip string
+		n  int
+	)
+
+	for n = range hosts {
+		if ip, _, _ = strings.Cut(hosts[n], "%"); fn(net.ParseIP(ip)) {
+			ret = []string{hosts[n]}
+			return
+		}
+	}
+
+	return
+}
+
+// Открытие TCP/IP порта на всех адресах, в которые разрешается имя хоста конфигурации сервера.
+// Если адресов или сокетов ReusePortShards несколько, соединения всех сокетов передаются через один слушатель.
+func listenResolved(conf *Configuration) (ret net.Listener, err error) {
+	var listeners []net.Listener
+
+	if listeners, err = listenResolvedAll(conf); err != nil {
+		return
+	}
+	switch len(listeners) {
+	case 1:
+		ret = listeners[0]
+	default:
+		ret = newMultiListener(listeners)
+	}
+
+	return
+}
+
+// Открытие TCP/IP порта на всех адресах, в которые разрешается имя хоста конфигурации сервера, для каждого
+// адреса открываются все сокеты ReusePortShards. При ошибке все открытые сокеты закрываются.
+func listenResolvedAll(conf *Configuration) (ret []net.Listener, err error) {
+	var (
+		lcf    *net.ListenConfig
+		hosts  []string
+		shards []net.Listener
+		n      int
+	)
+
+	if lcf, err = listenConfig(conf); err != nil {
+		return
+	}
+	if hosts, err = resolveHost(conf); err != nil {
+		return
+	}
+	for n = range hosts {
+		shards, err = listenShards(lcf, conf, hosts[n])
+		if ret = append(ret, shards...); err != nil {
+			closeListeners(ret)
+			ret = nil
+			return
+		}
+	}
+
+	return
+}
+
+// Открытие UDP порта на адресе, в который разрешается имя хоста конфигурации сервера.
+func listenPacketResolved(conf *Configuration) (ret net.PacketConn, err error) {
+	var (
+		lcf   *net.ListenConfig
+		hosts []string
+	)
+
+	if lcf, err = listenConfig(conf); err != nil {
+		return
+	}
+	if hosts, err = resolveHost(conf); err != nil {
+		return
+	}
+	if len(hosts) > 1 {
+		err = fmt.Errorf("%w %q: %s", Errors().ListenUdpMultiple(), conf.Host, strings.Join(hosts, ", "))
+		return
+	}
+	ret, err = lcf.ListenPacket(
+		context.Background(), conf.Mode, net.JoinHostPort(hosts[0], strconv.Itoa(int(conf.Port))),
+	)
+
+	return
+}
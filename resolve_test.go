@@ -0,0 +1,94 @@
+package net
+
+import (
+	"errors"
+	"net"
+	"testing"
+)
+
+func TestResolveHost(t *testing.T) {
+	var tests = []struct {
+		conf  Configuration
+		hosts []string
+		err   error
+	}{
+		{Configuration{Host: ""}, []string{""}, nil},
+		{Configuration{Host: "127.0.0.1", HostResolve: HostResolveIPv4}, []string{"127.0.0.1"}, nil},
+		{Configuration{Host: "127.0.0.1", HostResolve: HostResolveIPv6}, nil, Errors().HostResolveNoAddress()},
+		{Configuration{Host: "127.0.0.1", HostResolve: "bogus"}, nil, Errors().HostResolvePolicy()},
+		{Configuration{Host: "127.0.0.1", Mode: netTcp6}, nil, Errors().HostResolveNoAddress()},
+		{Configuration{Host: "::1", Mode: netUdp4}, nil, Errors().HostResolveNoAddress()},
+		{Configuration{Host: "", HostResolve: "bogus"}, nil, Errors().HostResolvePolicy()},
+		{Configuration{Host: "[::1]"}, []string{"::1"}, nil},
+		{Configuration{Host: "fe80::1%eth0"}, []string{"fe80::1%eth0"}, nil},
+		{Configuration{Host: "localhost", Mode: netTcp4}, []string{"127.0.0.1"}, nil},
+		{Configuration{Host: "localhost", HostResolve: HostResolveIPv4}, []string{"127.0.0.1"}, nil},
+		{Configuration{Host: "localhost", HostResolve: HostResolveIPv4, Mode: netTcp6}, nil, Errors().HostResolveNoAddress()},
+		{Configuration{Host: "localhost", HostResolve: "nearest"}, nil, Errors().HostResolvePolicy()},
+		{Configuration{Host: "host.invalid"}, nil, Errors().HostResolve()},
+	}
+
+	for n := range tests {
+		hosts, err := resolveHost(&tests[n].conf)
+		if !errors.Is(err, tests[n].err) {
+			t.Errorf("функция resolveHost(%q), ошибка: %v, ожидалось: %v", tests[n].conf.Host, err, tests[n].err)
+			continue
+		}
+		if len(hosts) != len(tests[n].hosts) || len(hosts) > 0 && hosts[0] != tests[n].hosts[0] {
+			t.Errorf("функция resolveHost(%q), вернулось: %q, ожидалось: %q", tests[n].conf.Host, hosts, tests[n].hosts)
+		}
+	}
+}
+
+// Политика single возвращает ошибку, если имя хоста разрешается в несколько адресов.
+func TestResolveHostSingle(t *testing.T) {
+	var (
+		err   error
+		all   []string
+		hosts []string
+	)
+
+	if all, err = resolveHost(&Configuration{Host: "localhost", HostResolve: HostResolveAll}); err != nil {
+		t.Fatalf("функция resolveHost(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	hosts, err = resolveHost(&Configuration{Host: "localhost", HostResolve: HostResolveSingle})
+	switch len(all) {
+	case 1:
+		if err != nil || len(hosts) != 1 {
+			t.Errorf("функция resolveHost(), вернулось: %q, ошибка: %v, ожидалось: %q, %v", hosts, err, all, nil)
+		}
+	default:
+		if !errors.Is(err, Errors().HostResolveAmbiguous()) {
+			t.Errorf("функция resolveHost(), ошибка: %v, ожидалось: %v", err, Errors().HostResolveAmbiguous())
+		}
+	}
+}
+
+// Политика all открывает порт на всех адресах, в которые разрешается имя хоста.
+func TestListenHostResolveAll(t *testing.T) {
+	var (
+		err   error
+		all   []string
+		nut   Interface
+		addrs []net.Addr
+		n     int
+	)
+
+	if all, err = resolveHost(&Configuration{Host: "localhost", HostResolve: HostResolveAll}); err != nil {
+		t.Fatalf("функция resolveHost(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	nut = New().Handler(testEchoHandler)
+	if err = nut.ListenAndServeWithConfig(&Configuration{Host: "localhost", HostResolve: HostResolveAll}).
+		Error(); err != nil {
+		t.Skipf("функция ListenAndServeWithConfig(), ошибка: %v", err)
+	}
+	defer nut.Stop()
+	if addrs = nut.BoundAddresses(); len(addrs) != len(all) {
+		t.Fatalf("функция BoundAddresses(), вернулось: %v, ожидалось адресов: %d", addrs, len(all))
+	}
+	for n = range addrs {
+		if ret := testEchoRequest(t, addrs[n], "ping"); ret != "ping" {
+			t.Errorf("ответ сервера на адресе %q: %q, ожидалось: %q", addrs[n], ret, "ping")
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package net
+
+import (
+	"context"
+	"net"
+	"strconv"
+)
+
+// NewListenerShards Создание отдельных слушателей для всех сокетов ReusePortShards, открытых на одном TCP/IP адресе
+// с опцией SO_REUSEPORT. Каждый слушатель имеет собственную очередь входящих соединений и может обслуживаться
+// отдельной горутиной. К слушателям применяются те же обёртки, что и в NewListener: TCPInfoOnClose, SocketPeerUID,
+// SocketPeerGID и ProxyProtocol. TLS не применяется, так как ключи сессионных билетов и запись секретов создаются
+// для каждого слушателя отдельно, для TLS каждый слушатель оборачивается через tls.NewListener с общей TLS
+// конфигурацией.
+func (nut *impl) NewListenerShards(conf *Configuration) (ret []net.Listener, err error) {
+	var n int
+
+	defaultConfiguration(conf)
+	switch conf.Mode {
+	case netTcp, netTcp4, netTcp6:
+	default:
+		err = Errors().ListenShards()
+		return
+	}
+	if !conf.ReusePort || len(conf.Listen) > 0 {
+		err = Errors().ListenShards()
+		return
+	}
+	if ret, err = listenResolvedAll(conf); err != nil {
+		return
+	}
+	for n = range ret {
+		ret[n] = proxyProtocolListener(conf, peerCredentialsListener(conf, nut.tcpInfoListener(conf, ret[n])))
+	}
+
+	return
+}
+
+// Открытие всех сокетов ReusePortShards на одном IP адресе. Сокеты, открытые до ошибки, возвращаются вместе с
+// ошибкой, закрытие сокетов выполняет вызывающая функция.
+func listenShards(lcf *net.ListenConfig, conf *Configuration, host string) (ret []net.Listener, err error) {
+	var (
+		ltn    net.Listener
+		port   string
+		shards int
+		n      int
+	)
+
+	if shards = 1; conf.ReusePort && conf.ReusePortShards > 1 {
+		shards = int(conf.ReusePortShards)
+	}
+	port = strconv.Itoa(int(conf.Port))
+	for n = 0; n < shards; n++ {
+		if ltn, err = lcf.Listen(context.Background(), conf.Mode, net.JoinHostPort(host, port)); err != nil {
+			return
+		}
+		// Все сокеты открываются на порту первого сокета, в том числе на порту, назначенном операционной системой.
+		if tcp, ok := ltn.Addr().(*net.TCPAddr); ok && n == 0 {
+			port = strconv.Itoa(tcp.Port)
+		}
+		ret = append(ret, ltn)
+	}
+	if conf.ReusePortCPUSteering && shards > 1 {
+		if err = reusePortSteering(ret[0], shards); err != nil {
+			return
+		}
+	}
+	for n = range ret {
+		if ret[n], err = socketListener(conf, ret[n]); err != nil {
+			return
+		}
+	}
+
+	return
+}
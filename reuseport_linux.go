@@ -0,0 +1,55 @@
+//go:build linux
+
+package net
+
+import (
+	"fmt"
+	"net"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// Смещение дополнительных данных classic BPF (SKF_AD_OFF, равно -0x1000) и номер процессора (SKF_AD_CPU),
+// linux/filter.h.
+const (
+	skfAdOff = 0xfffff000
+	skfAdCpu = 36
+)
+
+// Подключение к группе сокетов SO_REUSEPORT программы classic BPF, выбирающей сокет по номеру процессора,
+// обработавшего пакет: номер сокета равен номеру процессора по модулю количества сокетов.
+// Программа подключается к одному сокету и действует для всей группы.
+func reusePortSteering(ltn net.Listener, shards int) (err error) {
+	var (
+		sc      syscall.Conn
+		raw     syscall.RawConn
+		filters []unix.SockFilter
+		ok      bool
+	)
+
+	if sc, ok = ltn.(syscall.Conn); !ok {
+		return
+	}
+	if raw, err = sc.SyscallConn(); err != nil {
+		return
+	}
+	filters = []unix.SockFilter{
+		{Code: unix.BPF_LD | unix.BPF_W | unix.BPF_ABS, K: skfAdOff + skfAdCpu},
+		{Code: unix.BPF_ALU | unix.BPF_MOD | unix.BPF_K, K: uint32(shards)},
+		{Code: unix.BPF_RET | unix.BPF_A},
+	}
+	if e := raw.Control(func(fd uintptr) {
+		err = unix.SetsockoptSockFprog(int(fd), unix.SOL_SOCKET, unix.SO_ATTACH_REUSEPORT_CBPF, &unix.SockFprog{
+			Len:    uint16(len(filters)),
+			Filter: &filters[0],
+		})
+	}); e != nil {
+		err = e
+	}
+	if err != nil {
+		err = fmt.Errorf("%w SO_ATTACH_REUSEPORT_CBPF: %s", Errors().SocketOption(), err)
+	}
+
+	return
+}
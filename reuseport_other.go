@@ -0,0 +1,11 @@
+//go:build !linux
+
+package net
+
+import "net"
+
+// Распределение соединений по номеру процессора поддерживается только в Linux.
+func reusePortSteering(_ net.Listener, _ int) (err error) {
+	err = Errors().SocketOptionNotSupported()
+	return
+}
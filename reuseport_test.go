@@ -0,0 +1,76 @@
+package net
+
+import (
+	"errors"
+	"net"
+	"testing"
+)
+
+func TestNewListenerShards(t *testing.T) {
+	const shards = 4
+	var (
+		err       error
+		listeners []net.Listener
+		addr      string
+		n         int
+	)
+
+	if listeners, err = New().NewListenerShards(&Configuration{
+		Host:            "127.0.0.1",
+		ReusePort:       true,
+		ReusePortShards: shards,
+		TCPInfoOnClose:  true,
+	}); errors.Is(err, Errors().SocketOptionNotSupported()) {
+		t.Skipf("функция NewListenerShards(), ошибка: %v", err)
+	} else if err != nil {
+		t.Fatalf("функция NewListenerShards(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer closeListeners(listeners)
+	if len(listeners) != shards {
+		t.Fatalf("функция NewListenerShards(), количество слушателей: %d, ожидалось: %d", len(listeners), shards)
+	}
+	for n = range listeners {
+		if addr == "" {
+			addr = listeners[n].Addr().String()
+		}
+		if listeners[n].Addr().String() != addr {
+			t.Errorf("функция NewListenerShards(), адрес: %q, ожидалось: %q", listeners[n].Addr(), addr)
+		}
+		if _, ok := listeners[n].(*tcpInfoListener); !ok {
+			t.Errorf("функция NewListenerShards(), слушатель: %T, ожидалось: %T", listeners[n], &tcpInfoListener{})
+		}
+	}
+	if _, err = New().NewListenerShards(&Configuration{Host: "127.0.0.1"}); !errors.Is(err, Errors().ListenShards()) {
+		t.Errorf("функция NewListenerShards(), ошибка: %v, ожидалось: %v", err, Errors().ListenShards())
+	}
+}
+
+func TestListenReusePortMerged(t *testing.T) {
+	var (
+		err   error
+		nut   Interface
+		addrs []net.Addr
+		n     int
+	)
+
+	nut = New().Handler(testEchoHandler)
+	if err = nut.ListenAndServeWithConfig(&Configuration{
+		Host:                 "127.0.0.1",
+		ReusePort:            true,
+		ReusePortShards:      3,
+		ReusePortCPUSteering: true,
+	}).Error(); errors.Is(err, Errors().SocketOptionNotSupported()) || errors.Is(err, Errors().SocketOption()) {
+		t.Skipf("функция ListenAndServeWithConfig(), ошибка: %v", err)
+	} else if err != nil {
+		t.Fatalf("функция ListenAndServeWithConfig(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer nut.Stop()
+	if addrs = nut.BoundAddresses(); len(addrs) != 3 {
+		t.Fatalf("функция BoundAddresses(), количество адресов: %d, ожидалось: %d", len(addrs), 3)
+	}
+	for n = 0; n < 10; n++ {
+		if ret := testEchoRequest(t, addrs[0], "ping"); ret != "ping" {
+			t.Errorf("ответ сервера: %q, ожидалось: %q", ret, "ping")
+		}
+	}
+}
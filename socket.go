@@ -0,0 +1,112 @@
+package net
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"syscall"
+)
+
+// Функция настройки сокета перед открытием порта, вызываемая net.ListenConfig.
+type socketControlFn func(network string, address string, c syscall.RawConn) error
+
+// Слушатель соединений, отключающий TCP_NODELAY для принятых соединений.
+type noDelayListener struct {
+	net.Listener
+}
+
+// Создание настроек открытия сокета на основе конфигурации сервера.
+func listenConfig(conf *Configuration) (ret *net.ListenConfig, err error) {
+	var (
+		device   string
+		controls []socketControlFn
+		fn       socketControlFn
+	)
+
+	ret = new(net.ListenConfig)
+	if conf.BindToDevice {
+		if device = strings.TrimSuffix(strings.TrimPrefix(conf.Host, "["), "]"); device == "" {
+			err = Errors().BindToDevice()
+			return
+		}
+		if _, err = net.InterfaceByName(device); err != nil {
+			err = fmt.Errorf("%w %q: %s", Errors().BindToDevice(), device, err)
+			return
+		}
+		if fn, err = bindToDeviceControl(device); err != nil {
+			return
+		}
+		controls = append(controls, fn)
+	}
+	if isSocketOptions(conf) {
+		if fn, err = socketControl(conf); err != nil {
+			return
+		}
+		controls = append(controls, fn)
+	}
+	switch conf.Mode {
+	case netTcp, netTcp4, netTcp6:
+		ret.SetMultipathTCP(conf.MultipathTCP)
+	}
+	// Параметры keepalive, установленные на сокет слушателя, не должны перезаписываться средой выполнения Go.
+	if conf.KeepAliveDisable || isKeepAliveOptions(conf) {
+		ret.KeepAlive = -1
+	}
+	if len(controls) > 0 {
+		ret.Control = func(network string, address string, c syscall.RawConn) (err error) {
+			var n int
+
+			for n = range controls {
+				if err = controls[n](network, address, c); err != nil {
+					return
+				}
+			}
+
+			return
+		}
+	}
+
+	return
+}
+
+// Возвращается истина, если в конфигурации указаны параметры keepalive.
+func isKeepAliveOptions(conf *Configuration) bool {
+	return !conf.KeepAliveDisable && (conf.KeepAliveIdle > 0 || conf.KeepAliveInterval > 0 || conf.KeepAliveCount > 0)
+}
+
+// Возвращается истина, если в конфигурации указаны опции сокета, устанавливаемые до открытия порта.
+func isSocketOptions(conf *Configuration) bool {
+	return isKeepAliveOptions(conf) ||
+		conf.SocketReceiveBuffer > 0 || conf.SocketSendBuffer > 0 ||
+		conf.TCPDeferAccept > 0 || conf.TCPFastOpen > 0 || conf.TCPUserTimeout > 0 ||
+		conf.IPv6Only || conf.FreeBind || conf.SocketMark > 0 || conf.ReusePort ||
+		conf.Transparent
+}
+
+// Применение настроек конфигурации сервера к открытому слушателю TCP соединений.
+// При ошибке возвращается исходный слушатель, закрытие слушателя выполняет вызывающая функция.
+func socketListener(conf *Configuration, ltn net.Listener) (ret net.Listener, err error) {
+	if ret = ltn; conf.ListenBacklog > 0 {
+		if err = listenBacklog(ltn, conf.ListenBacklog); err != nil {
+			err = fmt.Errorf("%w backlog: %s", Errors().SocketOption(), err)
+			return
+		}
+	}
+	if conf.TCPNoDelayDisable {
+		ret = &noDelayListener{Listener: ltn}
+	}
+
+	return
+}
+
+// Accept Ожидание соединения и отключение TCP_NODELAY для принятого соединения.
+func (ndl *noDelayListener) Accept() (ret net.Conn, err error) {
+	if ret, err = ndl.Listener.Accept(); err != nil {
+		return
+	}
+	if tcp, ok := ret.(*net.TCPConn); ok {
+		_ = tcp.SetNoDelay(false)
+	}
+
+	return
+}
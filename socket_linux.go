@@ -0,0 +1,125 @@
+//go:build linux
+
+package net
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// Опция сокета.
+type socketOption struct {
+	name   string // Название опции.
+	level  int    // Уровень протокола.
+	option int    // Опция.
+	value  int    // Значение опции.
+	enable bool   // Опция устанавливается.
+}
+
+// Функция настройки сокета, устанавливающая опции сокета из конфигурации сервера.
+func socketControl(conf *Configuration) (ret socketControlFn, err error) {
+	ret = func(network string, address string, c syscall.RawConn) (err error) {
+		if e := c.Control(func(fd uintptr) { err = socketOptionsApply(int(fd), network, conf) }); e != nil {
+			err = e
+		}
+
+		return
+	}
+
+	return
+}
+
+// Установка опций сокета, network - тип сокета, переданный net.ListenConfig: tcp4, tcp6, udp4, udp6.
+func socketOptionsApply(fd int, network string, conf *Configuration) (err error) {
+	var (
+		isTcp   bool
+		isIPv6  bool
+		options []socketOption
+		n       int
+	)
+
+	isTcp, isIPv6 = strings.HasPrefix(network, netTcp), strings.HasSuffix(network, "6")
+	options = []socketOption{
+		{"SO_RCVBUF", unix.SOL_SOCKET, unix.SO_RCVBUF, int(conf.SocketReceiveBuffer), conf.SocketReceiveBuffer > 0},
+		{"SO_SNDBUF", unix.SOL_SOCKET, unix.SO_SNDBUF, int(conf.SocketSendBuffer), conf.SocketSendBuffer > 0},
+		{"SO_MARK", unix.SOL_SOCKET, unix.SO_MARK, int(conf.SocketMark), conf.SocketMark > 0},
+		{"IP_FREEBIND", unix.SOL_IP, unix.IP_FREEBIND, 1, conf.FreeBind},
+		{"SO_REUSEPORT", unix.SOL_SOCKET, unix.SO_REUSEPORT, 1, conf.ReusePort},
+		{"IP_TRANSPARENT", unix.SOL_IP, unix.IP_TRANSPARENT, 1, conf.Transparent},
+		{"IPV6_TRANSPARENT", unix.SOL_IPV6, unix.IPV6_TRANSPARENT, 1, conf.Transparent && isIPv6},
+		{"IP_RECVORIGDSTADDR", unix.SOL_IP, unix.IP_RECVORIGDSTADDR, 1, conf.Transparent && !isTcp},
+		{
+			"IPV6_RECVORIGDSTADDR", unix.SOL_IPV6, unix.IPV6_RECVORIGDSTADDR, 1,
+			conf.Transparent && !isTcp && isIPv6,
+		},
+		{"IPV6_V6ONLY", unix.IPPROTO_IPV6, unix.IPV6_V6ONLY, 1, conf.IPv6Only && isIPv6},
+		{"SO_KEEPALIVE", unix.SOL_SOCKET, unix.SO_KEEPALIVE, 1, isTcp && isKeepAliveOptions(conf)},
+		{
+			"TCP_KEEPIDLE", unix.IPPROTO_TCP, unix.TCP_KEEPIDLE, durationUnits(conf.KeepAliveIdle, time.Second),
+			isTcp && isKeepAliveOptions(conf) && conf.KeepAliveIdle > 0,
+		},
+		{
+			"TCP_KEEPINTVL", unix.IPPROTO_TCP, unix.TCP_KEEPINTVL, durationUnits(conf.KeepAliveInterval, time.Second),
+			isTcp && isKeepAliveOptions(conf) && conf.KeepAliveInterval > 0,
+		},
+		{
+			"TCP_KEEPCNT", unix.IPPROTO_TCP, unix.TCP_KEEPCNT, int(conf.KeepAliveCount),
+			isTcp && isKeepAliveOptions(conf) && conf.KeepAliveCount > 0,
+		},
+		{
+			"TCP_DEFER_ACCEPT", unix.IPPROTO_TCP, unix.TCP_DEFER_ACCEPT, durationUnits(conf.TCPDeferAccept, time.Second),
+			isTcp && conf.TCPDeferAccept > 0,
+		},
+		{"TCP_FASTOPEN", unix.IPPROTO_TCP, unix.TCP_FASTOPEN, int(conf.TCPFastOpen), isTcp && conf.TCPFastOpen > 0},
+		{
+			"TCP_USER_TIMEOUT", unix.IPPROTO_TCP, unix.TCP_USER_TIMEOUT,
+			durationUnits(conf.TCPUserTimeout, time.Millisecond), isTcp && conf.TCPUserTimeout > 0,
+		},
+	}
+	for n = range options {
+		if !options[n].enable {
+			continue
+		}
+		if err = unix.SetsockoptInt(fd, options[n].level, options[n].option, options[n].value); err != nil {
+			err = fmt.Errorf("%w %s: %s", Errors().SocketOption(), options[n].name, err)
+			return
+		}
+	}
+
+	return
+}
+
+// Изменение длины очереди ожидающих соединений открытого слушателя повторным вызовом listen().
+func listenBacklog(ltn net.Listener, backlog uint) (err error) {
+	var (
+		sc  syscall.Conn
+		raw syscall.RawConn
+		ok  bool
+	)
+
+	if sc, ok = ltn.(syscall.Conn); !ok {
+		return
+	}
+	if raw, err = sc.SyscallConn(); err != nil {
+		return
+	}
+	if e := raw.Control(func(fd uintptr) { err = unix.Listen(int(fd), int(backlog)) }); e != nil {
+		err = e
+	}
+
+	return
+}
+
+// Значение интервала времени в указанных единицах, не менее одной единицы для не нулевого интервала.
+func durationUnits(d time.Duration, unit time.Duration) (ret int) {
+	if ret = int(d / unit); ret == 0 && d > 0 {
+		ret = 1
+	}
+
+	return
+}
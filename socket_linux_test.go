@@ -0,0 +1,114 @@
+//go:build linux
+
+package net
+
+import (
+	"errors"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// Получение значения опции сокета слушателя.
+func testSocketOption(t *testing.T, ltn net.Listener, level int, option int) (ret int) {
+	var (
+		err error
+		raw syscall.RawConn
+	)
+
+	if raw, err = ltn.(syscall.Conn).SyscallConn(); err != nil {
+		t.Fatalf("функция SyscallConn(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	if e := raw.Control(func(fd uintptr) { ret, err = unix.GetsockoptInt(int(fd), level, option) }); e != nil {
+		err = e
+	}
+	if err != nil {
+		t.Skipf("функция GetsockoptInt(), опция %d, ошибка: %v", option, err)
+	}
+
+	return
+}
+
+func TestSocketOptions(t *testing.T) {
+	var (
+		err error
+		ltn net.Listener
+	)
+
+	if ltn, _, err = New().NewListener(&Configuration{
+		Host:              "127.0.0.1",
+		KeepAliveIdle:     time.Minute,
+		KeepAliveInterval: 10 * time.Second,
+		KeepAliveCount:    4,
+		TCPUserTimeout:    1500 * time.Millisecond,
+		TCPDeferAccept:    3 * time.Second,
+		ListenBacklog:     16,
+	}); errors.Is(err, Errors().SocketOption()) {
+		t.Skipf("функция NewListener(), ошибка: %v", err)
+	} else if err != nil {
+		t.Fatalf("функция NewListener(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer func() { _ = ltn.Close() }()
+	var tests = []struct {
+		name   string
+		level  int
+		option int
+		value  int
+	}{
+		{"SO_KEEPALIVE", unix.SOL_SOCKET, unix.SO_KEEPALIVE, 1},
+		{"TCP_KEEPIDLE", unix.IPPROTO_TCP, unix.TCP_KEEPIDLE, 60},
+		{"TCP_KEEPINTVL", unix.IPPROTO_TCP, unix.TCP_KEEPINTVL, 10},
+		{"TCP_KEEPCNT", unix.IPPROTO_TCP, unix.TCP_KEEPCNT, 4},
+		{"TCP_USER_TIMEOUT", unix.IPPROTO_TCP, unix.TCP_USER_TIMEOUT, 1500},
+	}
+	for n := range tests {
+		if ret := testSocketOption(t, ltn, tests[n].level, tests[n].option); ret != tests[n].value {
+			t.Errorf("опция сокета %s: %d, ожидалось: %d", tests[n].name, ret, tests[n].value)
+		}
+	}
+}
+
+func TestSocketOptionsIPv6Only(t *testing.T) {
+	var (
+		err error
+		ltn net.Listener
+	)
+
+	if ltn, _, err = New().NewListener(&Configuration{Host: "::", IPv6Only: true}); err != nil {
+		t.Skipf("функция NewListener(), ошибка: %v", err)
+	}
+	defer func() { _ = ltn.Close() }()
+	if ret := testSocketOption(t, ltn, unix.IPPROTO_IPV6, unix.IPV6_V6ONLY); ret != 1 {
+		t.Errorf("опция сокета IPV6_V6ONLY: %d, ожидалось: %d", ret, 1)
+	}
+}
+
+func TestSocketOptionsBuffers(t *testing.T) {
+	const size = 65536
+	var (
+		err error
+		lpc net.PacketConn
+		raw syscall.RawConn
+		ret int
+	)
+
+	if _, lpc, err = New().NewListener(&Configuration{
+		Mode:                netUdp,
+		Host:                "127.0.0.1",
+		SocketReceiveBuffer: size,
+	}); err != nil {
+		t.Fatalf("функция NewListener(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer func() { _ = lpc.Close() }()
+	if raw, err = lpc.(syscall.Conn).SyscallConn(); err != nil {
+		t.Fatalf("функция SyscallConn(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	_ = raw.Control(func(fd uintptr) { ret, err = unix.GetsockoptInt(int(fd), unix.SOL_SOCKET, unix.SO_RCVBUF) })
+	// Ядро Linux удваивает запрошенный размер буфера для служебных данных.
+	if err != nil || ret < size {
+		t.Errorf("опция сокета SO_RCVBUF: %d, ошибка: %v, ожидалось не менее: %d", ret, err, size)
+	}
+}
@@ -0,0 +1,17 @@
+//go:build !linux
+
+package net
+
+import "net"
+
+// Опции сокета поддерживаются только в Linux.
+func socketControl(_ *Configuration) (ret socketControlFn, err error) {
+	err = Errors().SocketOptionNotSupported()
+	return
+}
+
+// Изменение длины очереди ожидающих соединений поддерживается только в Linux.
+func listenBacklog(_ net.Listener, _ uint) (err error) {
+	err = Errors().SocketOptionNotSupported()
+	return
+}
@@ -0,0 +1,55 @@
+package net
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+func TestListenConfigKeepAlive(t *testing.T) {
+	var tests = []struct {
+		conf      Configuration
+		keepAlive time.Duration
+		control   bool
+	}{
+		{Configuration{}, 0, false},
+		{Configuration{KeepAliveDisable: true}, -1, false},
+		{Configuration{KeepAliveDisable: true, KeepAliveIdle: time.Minute}, -1, false},
+		{Configuration{KeepAliveIdle: time.Minute}, -1, true},
+		{Configuration{SocketMark: 1}, 0, true},
+	}
+
+	for n := range tests {
+		lcf, err := listenConfig(&tests[n].conf)
+		if err != nil {
+			t.Errorf("функция listenConfig(), ошибка: %v, ожидалось: %v", err, nil)
+			continue
+		}
+		if lcf.KeepAlive != tests[n].keepAlive || (lcf.Control != nil) != tests[n].control {
+			t.Errorf("функция listenConfig(), KeepAlive: %v, Control: %t, ожидалось: %v, %t",
+				lcf.KeepAlive, lcf.Control != nil, tests[n].keepAlive, tests[n].control)
+		}
+	}
+}
+
+func TestListenTCPNoDelayDisable(t *testing.T) {
+	var (
+		err error
+		nut Interface
+	)
+
+	nut = New().Handler(func(ltn net.Listener) (err error) {
+		if _, ok := ltn.(*noDelayListener); !ok {
+			t.Errorf("слушатель: %T, ожидался: %T", ltn, &noDelayListener{})
+		}
+		return testEchoHandler(ltn)
+	})
+	if err = nut.ListenAndServeWithConfig(&Configuration{Host: "127.0.0.1", TCPNoDelayDisable: true}).
+		Error(); err != nil {
+		t.Fatalf("функция ListenAndServeWithConfig(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer nut.Stop()
+	if ret := testEchoRequest(t, nut.Addr(), "ping"); ret != "ping" {
+		t.Errorf("ответ сервера: %q, ожидалось: %q", ret, "ping")
+	}
+}
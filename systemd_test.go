@@ -168,6 +168,7 @@ func TestNewListener(t *testing.T) {
 		err  error
 		nut  Interface
 		sar  []string
+		ltn  net.Listener
 		okFn func(*os.File) (net.Listener, error)
 		erFn func(*os.File) (net.Listener, error)
 	)
@@ -225,6 +226,13 @@ func TestNewListener(t *testing.T) {
 	if _, _, err = nut.NewListener(&Configuration{Mode: keySystemd, Socket: s0}); err != nil {
 		t.Errorf("функция NewListener() повреждена, ошибка не ожидалась")
 	}
+	// Сбор статистики TCP соединений для TCP сокета, переданного systemd.
+	if ltn, _, err = nut.NewListener(&Configuration{Mode: keySystemd, Socket: s0, TCPInfoOnClose: true}); err != nil {
+		t.Errorf("функция NewListener(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	if _, ok := ltn.(*tcpInfoListener); !ok {
+		t.Errorf("функция NewListener(), вернулось: %T, ожидалось: %T", ltn, &tcpInfoListener{})
+	}
 }
 
 func TestListenersSystemdTLSWithoutNames(t *testing.T) {
@@ -0,0 +1,127 @@
+package net
+
+import (
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// ConnTCPInfo Статистика TCP соединения (TCP_INFO): время приёма-передачи, повторные передачи, окно перегрузки,
+// скорость доставки. Соединение может быть обёрнуто в TLS, прокси-протокол или соединение мультиплексора,
+// статистика читается из исходного TCP соединения. Только для Linux.
+func ConnTCPInfo(conn net.Conn) (ret *TCPInfo, err error) {
+	for ; conn != nil; conn = unwrapConn(conn) {
+		if tcp, ok := conn.(*net.TCPConn); ok {
+			ret, err = tcpInfo(tcp)
+			return
+		}
+	}
+	err = Errors().TCPInfoConn()
+
+	return
+}
+
+// TCPInfoMetrics Метрики статистики TCP соединений, собранные при закрытии соединений слушателей, созданных с
+// включённым TCPInfoOnClose.
+func (nut *impl) TCPInfoMetrics() TCPInfoMetrics { return nut.tcpInfo.get() }
+
+// OnTCPInfo Назначение функции, получающей адрес клиента и статистику каждого закрываемого соединения слушателей,
+// созданных с включённым TCPInfoOnClose. Функция вызывается только при успешном получении статистики.
+func (nut *impl) OnTCPInfo(fn TCPInfoFn) Interface { nut.tcpInfo.setFn(fn); return nut }
+
+// Конструктор объекта метрик статистики TCP соединений.
+func newTCPInfoMetrics() *tcpInfoMetrics { return &tcpInfoMetrics{lck: new(sync.Mutex)} }
+
+// Назначение функции, получающей статистику каждого закрываемого соединения.
+func (tim *tcpInfoMetrics) setFn(fn TCPInfoFn) {
+	tim.lck.Lock()
+	defer tim.lck.Unlock()
+	tim.fn = fn
+}
+
+// Добавление статистики закрываемого соединения в метрики и передача статистики функции OnTCPInfo.
+func (tim *tcpInfoMetrics) add(remote net.Addr, info *TCPInfo, err error) {
+	var fn TCPInfoFn
+
+	tim.lck.Lock()
+	if err != nil {
+		tim.metrics.Errors++
+		tim.lck.Unlock()
+		return
+	}
+	tim.metrics.Samples++
+	tim.rttSum, tim.varSum = tim.rttSum+info.RTT, tim.varSum+info.RTTVar
+	if info.RTT > tim.metrics.RTTMax {
+		tim.metrics.RTTMax = info.RTT
+	}
+	tim.metrics.TotalRetrans += uint64(info.TotalRetrans)
+	tim.metrics.Lost += uint64(info.Lost)
+	tim.metrics.BytesSent += info.BytesSent
+	tim.metrics.BytesRetrans += info.BytesRetrans
+	fn = tim.fn
+	tim.lck.Unlock()
+	if fn != nil {
+		fn(remote, info)
+	}
+}
+
+// Копия метрик статистики TCP соединений.
+func (tim *tcpInfoMetrics) get() (ret TCPInfoMetrics) {
+	tim.lck.Lock()
+	defer tim.lck.Unlock()
+	if ret = tim.metrics; ret.Samples > 0 {
+		ret.RTTAvg = tim.rttSum / time.Duration(ret.Samples)
+		ret.RTTVarAvg = tim.varSum / time.Duration(ret.Samples)
+	}
+
+	return
+}
+
+// Конструктор слушателя, собирающего статистику TCP соединений при их закрытии.
+func newTCPInfoListener(ltn net.Listener, metrics *tcpInfoMetrics) *tcpInfoListener {
+	return &tcpInfoListener{Listener: ltn, metrics: metrics}
+}
+
+// Accept Ожидание соединения, соединение собирает статистику при закрытии.
+func (til *tcpInfoListener) Accept() (ret net.Conn, err error) {
+	if ret, err = til.Listener.Accept(); err != nil {
+		return
+	}
+	if tcp, ok := ret.(*net.TCPConn); ok {
+		ret = &tcpInfoConn{Conn: ret, tcp: tcp, metrics: til.metrics, once: new(sync.Once)}
+	}
+
+	return
+}
+
+// Close Сбор статистики TCP соединения и закрытие соединения.
+func (tic *tcpInfoConn) Close() error {
+	tic.once.Do(func() {
+		var (
+			info *TCPInfo
+			err  error
+		)
+
+		info, err = ConnTCPInfo(tic.Conn)
+		tic.metrics.add(tic.Conn.RemoteAddr(), info, err)
+	})
+	return tic.Conn.Close()
+}
+
+// ReadFrom Передача данных в соединение через исходное TCP соединение, сохраняющая использование splice и sendfile
+// при копировании данных через io.Copy.
+func (tic *tcpInfoConn) ReadFrom(r io.Reader) (int64, error) { return tic.tcp.ReadFrom(r) }
+
+// WriteTo Передача данных из соединения через исходное TCP соединение, сохраняющая использование splice при
+// копировании данных через io.Copy.
+func (tic *tcpInfoConn) WriteTo(w io.Writer) (int64, error) { return io.Copy(w, tic.tcp) }
+
+// CloseWrite Закрытие передачи данных в исходном TCP соединении.
+func (tic *tcpInfoConn) CloseWrite() error { return tic.tcp.CloseWrite() }
+
+// NetConn Исходное TCP соединение.
+func (tic *tcpInfoConn) NetConn() net.Conn { return tic.Conn }
+
+// TCPInfo Текущая статистика TCP соединения.
+func (tic *tcpInfoConn) TCPInfo() (*TCPInfo, error) { return ConnTCPInfo(tic.Conn) }
@@ -0,0 +1,48 @@
+//go:build linux
+
+package net
+
+import (
+	"net"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// Чтение статистики TCP соединения опцией TCP_INFO.
+func tcpInfo(tcp *net.TCPConn) (ret *TCPInfo, err error) {
+	var (
+		raw  syscall.RawConn
+		info *unix.TCPInfo
+	)
+
+	if raw, err = tcp.SyscallConn(); err != nil {
+		return
+	}
+	if e := raw.Control(func(fd uintptr) {
+		info, err = unix.GetsockoptTCPInfo(int(fd), unix.IPPROTO_TCP, unix.TCP_INFO)
+	}); e != nil {
+		err = e
+	}
+	if err != nil {
+		return
+	}
+	ret = &TCPInfo{
+		RTT:           time.Duration(info.Rtt) * time.Microsecond,
+		RTTVar:        time.Duration(info.Rttvar) * time.Microsecond,
+		MinRTT:        time.Duration(info.Min_rtt) * time.Microsecond,
+		Retransmits:   info.Retransmits,
+		TotalRetrans:  info.Total_retrans,
+		Lost:          info.Lost,
+		SndCwnd:       info.Snd_cwnd,
+		SndMSS:        info.Snd_mss,
+		PMTU:          info.Pmtu,
+		DeliveryRate:  info.Delivery_rate,
+		BytesSent:     info.Bytes_sent,
+		BytesRetrans:  info.Bytes_retrans,
+		BytesReceived: info.Bytes_received,
+	}
+
+	return
+}
@@ -0,0 +1,11 @@
+//go:build !linux
+
+package net
+
+import "net"
+
+// Статистика TCP соединения поддерживается только в Linux.
+func tcpInfo(_ *net.TCPConn) (ret *TCPInfo, err error) {
+	err = Errors().SocketOptionNotSupported()
+	return
+}
@@ -0,0 +1,101 @@
+package net
+
+import (
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestConnTCPInfo(t *testing.T) {
+	var (
+		err     error
+		nut     Interface
+		info    *TCPInfo
+		metrics TCPInfoMetrics
+		end     time.Time
+		remote  chan net.Addr
+	)
+
+	remote = make(chan net.Addr, 1)
+	nut = New().Handler(testEchoHandler).OnTCPInfo(func(addr net.Addr, _ *TCPInfo) { remote <- addr })
+	if err = nut.ListenAndServeWithConfig(&Configuration{Host: "127.0.0.1", TCPInfoOnClose: true}).
+		Error(); err != nil {
+		t.Fatalf("функция ListenAndServeWithConfig(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer nut.Stop()
+	if ret := testEchoRequest(t, nut.Addr(), "ping"); ret != "ping" {
+		t.Errorf("ответ сервера: %q, ожидалось: %q", ret, "ping")
+	}
+	// Соединение закрывается сервером после отправки ответа.
+	for end = time.Now().Add(time.Second); time.Now().Before(end); time.Sleep(10 * time.Millisecond) {
+		if metrics = nut.TCPInfoMetrics(); metrics.Samples+metrics.Errors > 0 {
+			break
+		}
+	}
+	if metrics.Errors > 0 {
+		t.Skipf("статистика TCP соединения не поддерживается, ошибок: %d", metrics.Errors)
+	}
+	if metrics.Samples != 1 {
+		t.Errorf("функция TCPInfoMetrics(), Samples: %d, ожидалось: %d", metrics.Samples, 1)
+	}
+	if metrics.RTTAvg > metrics.RTTMax {
+		t.Errorf("функция TCPInfoMetrics(), RTTAvg: %v, RTTMax: %v", metrics.RTTAvg, metrics.RTTMax)
+	}
+	select {
+	case addr := <-remote:
+		if addr == nil || addr.Network() != "tcp" {
+			t.Errorf("функция OnTCPInfo(), адрес клиента: %v", addr)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("функция OnTCPInfo() не вызвана при закрытии соединения")
+	}
+	if conn, e := net.Dial(nut.Addr().Network(), nut.Addr().String()); e == nil {
+		if info, err = ConnTCPInfo(conn); errors.Is(err, Errors().SocketOptionNotSupported()) {
+			t.Logf("функция ConnTCPInfo(), ошибка: %v", err)
+		} else if err != nil || info.SndMSS == 0 {
+			t.Errorf("функция ConnTCPInfo(), вернулось: %+v, ошибка: %v, ожидалось: %v", info, err, nil)
+		}
+		_ = conn.Close()
+	}
+	c1, c2 := net.Pipe()
+	defer func() { _, _ = c1.Close(), c2.Close() }()
+	if info, err = ConnTCPInfo(c1); !errors.Is(err, Errors().TCPInfoConn()) || info != nil {
+		t.Errorf("функция ConnTCPInfo(), ошибка: %v, ожидалось: %v", err, Errors().TCPInfoConn())
+	}
+}
+
+// Соединение, собирающее статистику, сохраняет методы TCP соединения, используемые io.Copy и проксированием.
+func TestTCPInfoConnMethods(t *testing.T) {
+	var (
+		err  error
+		ltn  net.Listener
+		conn net.Conn
+		ok   bool
+	)
+
+	if ltn, err = net.Listen("tcp", "127.0.0.1:0"); err != nil {
+		t.Fatalf("функция Listen(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	ltn = newTCPInfoListener(ltn, newTCPInfoMetrics())
+	defer func() { _ = ltn.Close() }()
+	go func() {
+		if c, e := net.Dial("tcp", ltn.Addr().String()); e == nil {
+			_ = c.Close()
+		}
+	}()
+	if conn, err = ltn.Accept(); err != nil {
+		t.Fatalf("функция Accept(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer func() { _ = conn.Close() }()
+	if _, ok = conn.(io.ReaderFrom); !ok {
+		t.Errorf("соединение не реализует io.ReaderFrom")
+	}
+	if _, ok = conn.(io.WriterTo); !ok {
+		t.Errorf("соединение не реализует io.WriterTo")
+	}
+	if _, ok = conn.(interface{ CloseWrite() error }); !ok {
+		t.Errorf("соединение не реализует CloseWrite()")
+	}
+}
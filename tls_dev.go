@@ -0,0 +1,206 @@
+package net
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"time"
+)
+
+const (
+	devSelfSignedOrganization = "webnice/net development"
+	devSelfSignedLocalhost    = "localhost"
+	devSelfSignedCAFileMode   = 0644
+	devSelfSignedCAKeyMode    = 0600
+	devSelfSignedCAKeySuffix  = ".key"
+	devSelfSignedCAValidity   = time.Hour * 24 * 365
+	devSelfSignedValidity     = time.Hour * 24 * 30
+)
+
+// Возвращается истина, если для конфигурации необходимо создать самоподписанный сертификат.
+func isDevSelfSigned(conf *Configuration) bool {
+	return conf.DevSelfSigned && conf.TLSPublicKeyPEM == "" && conf.TLSPrivateKeyPEM == ""
+}
+
+// Создание, в памяти, сертификата сервера для режима разработки, подписанного центром сертификации режима
+// разработки. Сертификат выпускается для Host из конфигурации и для localhost, 127.0.0.1, ::1.
+// Ключ сертификата сервера создаётся по алгоритму ECDSA, для TLS 1.2 конфигурация по умолчанию содержит наборы шифров
+// ECDHE-ECDSA.
+func newDevSelfSignedCertificate(conf *Configuration) (ret tls.Certificate, err error) {
+	var (
+		caKey      crypto.Signer
+		key        *ecdsa.PrivateKey
+		tpl        *x509.Certificate
+		ca         *x509.Certificate
+		caDer, der []byte
+		ip         net.IP
+	)
+
+	if ca, caKey, err = devSelfSignedCA(conf); err != nil {
+		return
+	}
+	caDer = ca.Raw
+	if key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader); err != nil {
+		return
+	}
+	if tpl, err = newDevSelfSignedTemplate(time.Now(), devSelfSignedValidity); err != nil {
+		return
+	}
+	tpl.Subject.CommonName = devSelfSignedLocalhost
+	tpl.KeyUsage = x509.KeyUsageDigitalSignature
+	tpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	tpl.DNSNames = []string{devSelfSignedLocalhost}
+	tpl.IPAddresses = []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback}
+	switch ip = net.ParseIP(conf.Host); {
+	case conf.Host == "", conf.Host == devSelfSignedLocalhost:
+	case ip == nil:
+		tpl.Subject.CommonName = conf.Host
+		tpl.DNSNames = append(tpl.DNSNames, conf.Host)
+	case !ip.IsUnspecified() && !ip.IsLoopback():
+		tpl.IPAddresses = append(tpl.IPAddresses, ip)
+	}
+	if der, err = x509.CreateCertificate(rand.Reader, tpl, ca, &key.PublicKey, caKey); err != nil {
+		return
+	}
+	ret = tls.Certificate{
+		Certificate: [][]byte{der, caDer},
+		PrivateKey:  key,
+	}
+	ret.Leaf, err = x509.ParseCertificate(der)
+
+	return
+}
+
+// Центр сертификации режима разработки. Если указан DevSelfSignedCAFile, центр сертификации загружается из файла
+// сертификата и файла ключа "DevSelfSignedCAFile.key", поэтому сертификат, добавленный в список доверенных
+// сертификатов клиента, остаётся действительным после перезапуска сервера. Если файлы не существуют или срок
+// действия центра сертификации истекает, создаётся новый центр сертификации и сохраняется в файлы.
+func devSelfSignedCA(conf *Configuration) (ca *x509.Certificate, key crypto.Signer, err error) {
+	var (
+		caKey  *ecdsa.PrivateKey
+		caTpl  *x509.Certificate
+		caDer  []byte
+		keyPEM []byte
+	)
+
+	if conf.DevSelfSignedCAFile != "" {
+		switch ca, key, err = loadDevSelfSignedCA(conf.DevSelfSignedCAFile); {
+		case err == nil && time.Now().Add(devSelfSignedValidity).Before(ca.NotAfter):
+			return
+		case err == nil, errors.Is(err, os.ErrNotExist):
+			err = nil
+		default:
+			return
+		}
+	}
+	if caKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader); err != nil {
+		return
+	}
+	if caTpl, err = newDevSelfSignedTemplate(time.Now(), devSelfSignedCAValidity); err != nil {
+		return
+	}
+	caTpl.Subject.CommonName = devSelfSignedOrganization + " CA"
+	caTpl.IsCA, caTpl.BasicConstraintsValid = true, true
+	caTpl.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature
+	if caDer, err = x509.CreateCertificate(rand.Reader, caTpl, caTpl, &caKey.PublicKey, caKey); err != nil {
+		return
+	}
+	if ca, err = x509.ParseCertificate(caDer); err != nil {
+		return
+	}
+	key = caKey
+	if conf.DevSelfSignedCAFile == "" {
+		return
+	}
+	if keyPEM, err = marshalPrivateKeyPEM(caKey); err != nil {
+		return
+	}
+	if err = os.WriteFile(
+		conf.DevSelfSignedCAFile+devSelfSignedCAKeySuffix, keyPEM, devSelfSignedCAKeyMode,
+	); err != nil {
+		return
+	}
+	err = os.WriteFile(
+		conf.DevSelfSignedCAFile,
+		pem.EncodeToMemory(&pem.Block{Type: pemCertificate, Bytes: caDer}),
+		devSelfSignedCAFileMode,
+	)
+
+	return
+}
+
+// Загрузка центра сертификации режима разработки из файла сертификата и файла ключа.
+func loadDevSelfSignedCA(filename string) (ca *x509.Certificate, key crypto.Signer, err error) {
+	var (
+		crtPEM, keyPEM []byte
+		block          *pem.Block
+		pkey           any
+		ok             bool
+	)
+
+	if crtPEM, err = os.ReadFile(filename); err != nil {
+		return
+	}
+	if keyPEM, err = os.ReadFile(filename + devSelfSignedCAKeySuffix); err != nil {
+		return
+	}
+	if block, _ = pem.Decode(crtPEM); block == nil || block.Type != pemCertificate {
+		err = fmt.Errorf("%w %q", Errors().DevSelfSignedCA(), filename)
+		return
+	}
+	if ca, err = x509.ParseCertificate(block.Bytes); err != nil {
+		err = fmt.Errorf("%w %q: %s", Errors().DevSelfSignedCA(), filename, err)
+		return
+	}
+	if !ca.IsCA {
+		err = fmt.Errorf("%w %q", Errors().DevSelfSignedCA(), filename)
+		return
+	}
+	if block, _ = pem.Decode(keyPEM); block == nil {
+		err = fmt.Errorf("%w %q", Errors().DevSelfSignedCA(), filename+devSelfSignedCAKeySuffix)
+		return
+	}
+	if pkey, err = parsePrivateKeyDER(block.Bytes); err != nil {
+		err = fmt.Errorf("%w %q: %s", Errors().DevSelfSignedCA(), filename+devSelfSignedCAKeySuffix, err)
+		return
+	}
+	if key, ok = pkey.(crypto.Signer); !ok {
+		err = fmt.Errorf("%w %q", Errors().DevSelfSignedCA(), filename+devSelfSignedCAKeySuffix)
+		return
+	}
+	// Ключ должен соответствовать сертификату центра сертификации.
+	if pub, isEqual := key.Public().(interface{ Equal(crypto.PublicKey) bool }); !isEqual || !pub.Equal(ca.PublicKey) {
+		err = fmt.Errorf("%w %q", Errors().DevSelfSignedCA(), filename+devSelfSignedCAKeySuffix)
+		return
+	}
+
+	return
+}
+
+// Шаблон сертификата режима разработки со случайным серийным номером.
+func newDevSelfSignedTemplate(now time.Time, validity time.Duration) (ret *x509.Certificate, err error) {
+	const serialBits = 128
+	var serial *big.Int
+
+	if serial, err = rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), serialBits)); err != nil {
+		return
+	}
+	ret = &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{Organization: []string{devSelfSignedOrganization}},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(validity),
+	}
+
+	return
+}
@@ -0,0 +1,83 @@
+package net
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"os"
+	"path"
+	"testing"
+	"time"
+)
+
+func TestDevSelfSigned(t *testing.T) {
+	const testHost, testTimeout = "127.0.0.1", time.Second * 5
+	var (
+		err  error
+		nut  Interface
+		conf *Configuration
+		buf  []byte
+		pool *x509.CertPool
+		conn *tls.Conn
+		crt  tls.Certificate
+	)
+
+	conf = &Configuration{Host: "dev.example.local"}
+	if crt, err = newDevSelfSignedCertificate(conf); err != nil {
+		t.Fatalf("функция newDevSelfSignedCertificate(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	if err = crt.Leaf.VerifyHostname(conf.Host); err != nil {
+		t.Errorf("функция newDevSelfSignedCertificate(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	// Без DevSelfSigned сертификат не создаётся.
+	conf = &Configuration{Host: testHost}
+	if _, err = New().NewTLSConfig(conf); err == nil {
+		t.Errorf("функция NewTLSConfig(), ошибка: %v, ожидалась ошибка", err)
+	}
+	conf.DevSelfSigned, conf.DevSelfSignedCAFile = true, path.Join(t.TempDir(), "ca.pem")
+	nut = New().
+		Handler(testTcpHandler).
+		ListenAndServeTLSWithConfig(conf, nil)
+	if err = nut.Error(); err != nil {
+		t.Fatalf("функция ListenAndServeTLSWithConfig(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer nut.Stop()
+	if buf, err = os.ReadFile(conf.DevSelfSignedCAFile); err != nil {
+		t.Fatalf("чтение сертификата центра сертификации, ошибка: %v, ожидалось: %v", err, nil)
+	}
+	pool = x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(buf) {
+		t.Fatalf("не корректный сертификат центра сертификации")
+	}
+	if conn, err = tls.DialWithDialer(&net.Dialer{Timeout: testTimeout}, "tcp", nut.Addr().String(), &tls.Config{
+		RootCAs:    pool,
+		ServerName: devSelfSignedLocalhost,
+		MaxVersion: tls.VersionTLS12,
+	}); err != nil {
+		t.Fatalf("функция DialWithDialer(), TLS 1.2, ошибка: %v, ожидалось: %v", err, nil)
+	}
+	_ = conn.SetDeadline(time.Now().Add(testTimeout))
+	if suite := conn.ConnectionState().CipherSuite; suite != tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384 {
+		t.Errorf("набор шифров TLS 1.2: %s, ожидался: %s",
+			tls.CipherSuiteName(suite), tls.CipherSuiteName(tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384))
+	}
+	_ = conn.Close()
+	// Сохранённый центр сертификации используется повторно, сертификат нового сервера проверяется по нему.
+	if crt, err = newDevSelfSignedCertificate(conf); err != nil {
+		t.Fatalf("функция newDevSelfSignedCertificate(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	if _, err = crt.Leaf.Verify(x509.VerifyOptions{Roots: pool, DNSName: devSelfSignedLocalhost}); err != nil {
+		t.Errorf("проверка сертификата после перезапуска, ошибка: %v, ожидалось: %v", err, nil)
+	}
+	if fi, e := os.Stat(conf.DevSelfSignedCAFile + devSelfSignedCAKeySuffix); e != nil || fi.Mode().Perm() != 0600 {
+		t.Errorf("файл ключа центра сертификации, ошибка: %v", e)
+	}
+	// Ключ, не соответствующий сертификату центра сертификации, является ошибкой.
+	if err = os.WriteFile(conf.DevSelfSignedCAFile+devSelfSignedCAKeySuffix, getKeyEcdsa(), 0600); err != nil {
+		t.Fatalf("функция WriteFile(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	if _, err = newDevSelfSignedCertificate(conf); !errors.Is(err, Errors().DevSelfSignedCA()) {
+		t.Errorf("функция newDevSelfSignedCertificate(), ошибка: %v, ожидалось: %v", err, Errors().DevSelfSignedCA())
+	}
+}
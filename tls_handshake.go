@@ -0,0 +1,275 @@
+package net
+
+import (
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Причины ошибок TLS рукопожатия.
+const (
+	handshakeFailureTimeout = "timeout"      // Истекло время ожидания рукопожатия.
+	handshakeFailureClosed  = "closed"       // Клиент закрыл соединение.
+	handshakeFailureNotTLS  = "not_tls"      // Клиент прислал данные не являющиеся TLS.
+	handshakeFailureAlert   = "remote_alert" // Клиент прервал рукопожатие TLS сообщением об ошибке.
+	handshakeFailureTLS     = "tls"          // Ошибка протокола TLS на стороне сервера.
+	handshakeFailureOther   = "other"        // Прочие ошибки.
+)
+
+const (
+	defaultTLSHandshakeTimeout     = 10 * time.Second // Время ожидания рукопожатия по умолчанию.
+	defaultTLSHandshakeConcurrency = 1024             // Количество одновременных рукопожатий по умолчанию.
+)
+
+// Счётчики ошибок TLS рукопожатия по причинам.
+type handshakeFailures struct {
+	lck   *sync.Mutex
+	count map[string]uint64
+}
+
+// Слушатель TLS соединений, выполняющий рукопожатие до передачи соединения в обработчик.
+// Рукопожатие каждого соединения выполняется в отдельной горутине, поэтому медленный клиент не задерживает
+// приём остальных соединений. Количество одновременных рукопожатий ограничено, при достижении ограничения приём
+// новых соединений приостанавливается. Соединения с ошибкой рукопожатия закрываются и учитываются в счётчиках
+// ошибок, соединения с незавершённым рукопожатием закрываются при закрытии слушателя.
+type handshakeListener struct {
+	net.Listener                       // Слушатель соединений без TLS.
+	config       *tls.Config           // TLS конфигурация.
+	timeout      time.Duration         // Максимальное время рукопожатия.
+	failures     *handshakeFailures    // Счётчики ошибок рукопожатия.
+	conns        chan net.Conn         // Соединения с успешно выполненным рукопожатием.
+	errs         chan error            // Ошибки приёма соединений.
+	done         chan struct{}         // Канал закрытия слушателя.
+	once         *sync.Once            // Однократное закрытие слушателя.
+	sem          chan struct{}         // Ограничение количества одновременных рукопожатий.
+	lck          *sync.Mutex           // Защита списка соединений с незавершённым рукопожатием.
+	active       map[net.Conn]struct{} // Соединения с незавершённым рукопожатием.
+	closed       bool                  // Слушатель закрыт.
+}
+
+// Конструктор объекта счётчиков ошибок TLS рукопожатия.
+func newHandshakeFailures() *handshakeFailures {
+	return &handshakeFailures{lck: new(sync.Mutex), count: make(map[string]uint64)}
+}
+
+// Увеличение счётчика ошибок рукопожатия по причине.
+func (hsf *handshakeFailures) add(reason string) {
+	hsf.lck.Lock()
+	defer hsf.lck.Unlock()
+	hsf.count[reason]++
+}
+
+// Копия счётчиков ошибок рукопожатия.
+func (hsf *handshakeFailures) get() (ret map[string]uint64) {
+	var key string
+
+	hsf.lck.Lock()
+	defer hsf.lck.Unlock()
+	ret = make(map[string]uint64, len(hsf.count))
+	for key = range hsf.count {
+		ret[key] = hsf.count[key]
+	}
+
+	return
+}
+
+// TLSHandshakeFailures Счётчики ошибок TLS рукопожатия по причинам, для слушателей созданных с включённым
+// TLSHandshakeEager.
+func (nut *impl) TLSHandshakeFailures() map[string]uint64 { return nut.tlsFailures.get() }
+
+// Конструктор слушателя TLS соединений, выполняющего рукопожатие до передачи соединения в обработчик.
+func newHandshakeListener(
+	l net.Listener,
+	tlsConfig *tls.Config,
+	timeout time.Duration,
+	concurrency uint,
+	failures *handshakeFailures,
+) (ret *handshakeListener) {
+	if concurrency == 0 {
+		concurrency = defaultTLSHandshakeConcurrency
+	}
+	ret = &handshakeListener{
+		Listener: l,
+		config:   tlsConfig,
+		timeout:  timeout,
+		failures: failures,
+		conns:    make(chan net.Conn),
+		errs:     make(chan error),
+		done:     make(chan struct{}),
+		once:     new(sync.Once),
+		sem:      make(chan struct{}, concurrency),
+		lck:      new(sync.Mutex),
+		active:   make(map[net.Conn]struct{}),
+	}
+	go ret.acceptLoop()
+
+	return
+}
+
+// Accept Ожидание соединения с успешно выполненным TLS рукопожатием.
+// Возвращается соединение *tls.Conn, данные о согласованных ALPN и SNI доступны через ConnectionState().
+func (hsl *handshakeListener) Accept() (ret net.Conn, err error) {
+	select {
+	case ret = <-hsl.conns:
+	case err = <-hsl.errs:
+	case <-hsl.done:
+		err = net.ErrClosed
+	}
+
+	return
+}
+
+// Close Закрытие слушателя и соединений с незавершённым рукопожатием.
+func (hsl *handshakeListener) Close() (err error) {
+	var conn net.Conn
+
+	hsl.once.Do(func() { close(hsl.done) })
+	err = hsl.Listener.Close()
+	hsl.lck.Lock()
+	defer hsl.lck.Unlock()
+	hsl.closed = true
+	for conn = range hsl.active {
+		_ = conn.Close()
+	}
+	hsl.active = make(map[net.Conn]struct{})
+
+	return
+}
+
+// Добавление соединения в список соединений с незавершённым рукопожатием. Если слушатель закрыт, возвращается
+// ложь, соединение не добавляется.
+func (hsl *handshakeListener) track(conn net.Conn) bool {
+	hsl.lck.Lock()
+	defer hsl.lck.Unlock()
+	if hsl.closed {
+		return false
+	}
+	hsl.active[conn] = struct{}{}
+
+	return true
+}
+
+// Удаление соединения из списка соединений с незавершённым рукопожатием.
+func (hsl *handshakeListener) untrack(conn net.Conn) {
+	hsl.lck.Lock()
+	defer hsl.lck.Unlock()
+	delete(hsl.active, conn)
+}
+
+// Приём соединений и запуск рукопожатия для каждого соединения.
+func (hsl *handshakeListener) acceptLoop() {
+	var (
+		conn net.Conn
+		err  error
+	)
+
+	for {
+		if conn, err = hsl.Listener.Accept(); err != nil {
+			select {
+			case hsl.errs <- err:
+			case <-hsl.done:
+				return
+			}
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			continue
+		}
+		select {
+		case hsl.sem <- struct{}{}:
+		case <-hsl.done:
+			_ = conn.Close()
+			return
+		}
+		go hsl.handshake(conn)
+	}
+}
+
+// Выполнение TLS рукопожатия и передача соединения в Accept.
+func (hsl *handshakeListener) handshake(conn net.Conn) {
+	var (
+		tlsConn *tls.Conn
+		err     error
+	)
+
+	defer func() { <-hsl.sem }()
+	if !hsl.track(conn) {
+		_ = conn.Close()
+		return
+	}
+	tlsConn, err = serverHandshake(conn, hsl.config, hsl.timeout, hsl.failures)
+	if hsl.untrack(conn); err != nil {
+		return
+	}
+	select {
+	case hsl.conns <- tlsConn:
+	case <-hsl.done:
+		_ = tlsConn.Close()
+	}
+}
+
+// Выполнение TLS рукопожатия на стороне сервера с ограничением времени. Если время не указано, используется время
+// ожидания по умолчанию, чтобы клиент, не отправляющий ClientHello, не удерживал соединение бесконечно.
+// При ошибке рукопожатия соединение закрывается, ошибка учитывается в счётчиках ошибок по причинам.
+func serverHandshake(
+	conn net.Conn,
+	tlsConfig *tls.Config,
+	timeout time.Duration,
+	failures *handshakeFailures,
+) (ret *tls.Conn, err error) {
+	if timeout <= 0 {
+		timeout = defaultTLSHandshakeTimeout
+	}
+	ret = tls.Server(conn, tlsConfig)
+	_ = conn.SetDeadline(time.Now().Add(timeout))
+	if err = ret.Handshake(); err != nil {
+		failures.add(handshakeFailureReason(err))
+		_ = conn.Close()
+		ret = nil
+		return
+	}
+	_ = conn.SetDeadline(time.Time{})
+
+	return
+}
+
+// Определение причины ошибки TLS рукопожатия.
+// Сообщение клиента об ошибке возвращается пакетом crypto/tls как *net.OpError с операцией "remote error" или,
+// для QUIC, как tls.AlertError. Ошибки, не являющиеся сетевыми ошибками соединения, возвращаются протоколом TLS.
+func handshakeFailureReason(err error) (ret string) {
+	const (
+		opRemoteError = "remote error" // Операция ошибки, полученной от клиента.
+		opLocalError  = "local error"  // Операция ошибки, отправленной клиенту.
+	)
+	var (
+		ne  net.Error
+		oe  *net.OpError
+		ae  tls.AlertError
+		rhe tls.RecordHeaderError
+		cve *tls.CertificateVerificationError
+	)
+
+	switch {
+	case errors.Is(err, os.ErrDeadlineExceeded), errors.As(err, &ne) && ne.Timeout():
+		ret = handshakeFailureTimeout
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, syscall.ECONNRESET):
+		ret = handshakeFailureClosed
+	case errors.As(err, &rhe):
+		ret = handshakeFailureNotTLS
+	case errors.As(err, &ae), errors.As(err, &oe) && oe.Op == opRemoteError:
+		ret = handshakeFailureAlert
+	case errors.As(err, &cve):
+		ret = handshakeFailureTLS
+	case errors.As(err, &oe) && oe.Op != opLocalError:
+		ret = handshakeFailureOther
+	default:
+		ret = handshakeFailureTLS
+	}
+
+	return
+}
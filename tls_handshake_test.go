@@ -0,0 +1,161 @@
+package net
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestHandshakeFailureReason(t *testing.T) {
+	var tests = []struct {
+		err    error
+		reason string
+	}{
+		{io.EOF, handshakeFailureClosed},
+		{tls.RecordHeaderError{}, handshakeFailureNotTLS},
+		{&net.OpError{Op: "remote error", Err: errors.New("tls: bad certificate")}, handshakeFailureAlert},
+		{fmt.Errorf("tls: handshake: %w", tls.AlertError(42)), handshakeFailureAlert},
+		{&tls.CertificateVerificationError{Err: errors.New("n/a")}, handshakeFailureTLS},
+		{&net.OpError{Op: "local error", Err: errors.New("tls: no cipher suite supported")}, handshakeFailureTLS},
+		{errors.New("tls: client offered only unsupported versions"), handshakeFailureTLS},
+		{&net.OpError{Op: "write", Err: syscall.EPIPE}, handshakeFailureOther},
+	}
+
+	for n := range tests {
+		if ret := handshakeFailureReason(tests[n].err); ret != tests[n].reason {
+			t.Errorf(
+				"функция handshakeFailureReason(%q), вернулось: %q, ожидалось: %q",
+				tests[n].err, ret, tests[n].reason,
+			)
+		}
+	}
+}
+
+func TestHandshakeEager(t *testing.T) {
+	const (
+		timeout    = time.Millisecond * 100
+		serverName = "localhost"
+	)
+	var (
+		err      error
+		key      *tmpFile
+		crt      *tmpFile
+		conf     *Configuration
+		nut      Interface
+		lst      net.Listener
+		raw      [2]net.Conn
+		conn     *tls.Conn
+		accepted net.Conn
+		state    tls.ConnectionState
+		failures map[string]uint64
+	)
+
+	key, crt = newTmpFile(getKeyEcdsa()), newTmpFile(getCrtEcdsa())
+	defer func() { key.Clean(); crt.Clean() }()
+	conf = &Configuration{
+		Host:                "127.0.0.1",
+		TLSPublicKeyPEM:     crt.Filename,
+		TLSPrivateKeyPEM:    key.Filename,
+		TLSHandshakeEager:   true,
+		TLSHandshakeTimeout: timeout,
+	}
+	nut = New()
+	if lst, _, err = nut.NewListenerTLS(conf, nil); err != nil {
+		t.Fatalf("функция NewListenerTLS(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer func() { _ = lst.Close() }()
+	// Клиент без рукопожатия и клиент не использующий TLS.
+	for n := range raw {
+		if raw[n], err = net.Dial("tcp", lst.Addr().String()); err != nil {
+			t.Fatalf("функция Dial(), ошибка: %v, ожидалось: %v", err, nil)
+		}
+		defer func(c net.Conn) { _ = c.Close() }(raw[n])
+	}
+	if _, err = raw[1].Write([]byte("GET / HTTP/1.0\r\n\r\n")); err != nil {
+		t.Fatalf("функция Write(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	// Клиент TLS.
+	go func() {
+		if c, e := tls.Dial("tcp", lst.Addr().String(), &tls.Config{
+			InsecureSkipVerify: true,
+			ServerName:         serverName,
+		}); e == nil {
+			_, _ = io.ReadAll(c)
+			_ = c.Close()
+		}
+	}()
+	if accepted, err = lst.Accept(); err != nil {
+		t.Fatalf("функция Accept(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	conn = accepted.(*tls.Conn)
+	if state = conn.ConnectionState(); !state.HandshakeComplete || state.ServerName != serverName {
+		t.Errorf(
+			"рукопожатие: %t, SNI: %q, ожидалось: %t, %q",
+			state.HandshakeComplete, state.ServerName, true, serverName,
+		)
+	}
+	_ = conn.Close()
+	time.Sleep(timeout * 3)
+	failures = nut.TLSHandshakeFailures()
+	if failures[handshakeFailureTimeout] != 1 || failures[handshakeFailureNotTLS] != 1 {
+		t.Errorf("функция TLSHandshakeFailures(), вернулось: %v", failures)
+	}
+	if err = lst.Close(); err != nil {
+		t.Errorf("функция Close(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	if _, err = lst.Accept(); !errors.Is(err, net.ErrClosed) {
+		t.Errorf("функция Accept(), ошибка: %v, ожидалось: %v", err, net.ErrClosed)
+	}
+}
+
+// Количество одновременных рукопожатий ограничено, закрытие слушателя закрывает соединения с незавершённым
+// рукопожатием.
+func TestHandshakeListenerClose(t *testing.T) {
+	var (
+		err     error
+		raw     net.Listener
+		hsl     *handshakeListener
+		clients [2]net.Conn
+		active  int
+		n       int
+	)
+
+	if raw, err = net.Listen(netTcp, "127.0.0.1:0"); err != nil {
+		t.Fatalf("функция Listen(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	hsl = newHandshakeListener(raw, &tls.Config{}, time.Minute, 1, newHandshakeFailures())
+	for n = range clients {
+		if clients[n], err = net.Dial(netTcp, raw.Addr().String()); err != nil {
+			t.Fatalf("функция Dial(), ошибка: %v, ожидалось: %v", err, nil)
+		}
+		defer func(conn net.Conn) { _ = conn.Close() }(clients[n])
+	}
+	for n = 0; n < 100 && active == 0; n++ {
+		time.Sleep(time.Millisecond * 10)
+		hsl.lck.Lock()
+		active = len(hsl.active)
+		hsl.lck.Unlock()
+	}
+	time.Sleep(time.Millisecond * 50)
+	hsl.lck.Lock()
+	active = len(hsl.active)
+	hsl.lck.Unlock()
+	if active != 1 {
+		t.Errorf("количество рукопожатий: %d, ожидалось: %d", active, 1)
+	}
+	if err = hsl.Close(); err != nil {
+		t.Errorf("функция Close(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	for n = range clients {
+		_ = clients[n].SetReadDeadline(time.Now().Add(time.Second))
+		if _, err = clients[n].Read(make([]byte, 1)); err == nil || errors.Is(err, os.ErrDeadlineExceeded) {
+			t.Errorf("соединение %d не закрыто, ошибка: %v", n, err)
+		}
+	}
+}
@@ -0,0 +1,435 @@
+package net
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+	"os"
+	"path"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+const (
+	secretPrefixFile       = "file:"
+	secretPrefixEnv        = "env:"
+	secretPrefixCredential = "credential:"
+	envCredentialsDir      = "CREDENTIALS_DIRECTORY"
+	pemPrivateKey          = "PRIVATE KEY"
+	pemEncryptedPrivateKey = "ENCRYPTED PRIVATE KEY"
+	pemCertificate         = "CERTIFICATE"
+)
+
+// Идентификаторы алгоритмов шифрования PKCS#8 (RFC 8018).
+var (
+	oidPBES2          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2         = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidHmacWithSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHmacWithSHA224 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 8}
+	oidHmacWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidHmacWithSHA384 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 10}
+	oidHmacWithSHA512 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 11}
+	oidAES128CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES192CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	oidAES256CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+	oidDESEDE3CBC     = asn1.ObjectIdentifier{1, 2, 840, 113549, 3, 7}
+)
+
+// Зашифрованный секретный ключ в формате PKCS#8.
+type encryptedPrivateKeyInfo struct {
+	Algorithm     pkix.AlgorithmIdentifier
+	EncryptedData []byte
+}
+
+// Параметры схемы шифрования PBES2.
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+// Параметры функции формирования ключа PBKDF2.
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int                      `asn1:"optional"`
+	Prf            pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+// NewTLSConfig Создание TLS конфигурации по умолчанию на основе конфигурации сервера.
+// Секретный ключ может быть зашифрован или находиться в контейнере PKCS#12, пароль к ключу загружается из
+// источника указанного в TLSPrivateKeyPassword.
+// Если ключи не указаны и включён DevSelfSigned, создаётся самоподписанный сертификат для режима разработки.
+func (nut *impl) NewTLSConfig(conf *Configuration) (ret *tls.Config, err error) {
+	if conf == nil {
+		err = Errors().NoConfiguration()
+		return
+	}
+	ret = tlsConfigDefault()
+	ret.Certificates = make([]tls.Certificate, 1)
+	if isDevSelfSigned(conf) {
+		ret.Certificates[0], err = newDevSelfSignedCertificate(conf)
+		return
+	}
+	if ret.Certificates[0], err = loadX509KeyPair(
+		conf.TLSPublicKeyPEM,
+		conf.TLSPrivateKeyPEM,
+		conf.TLSPrivateKeyPassword,
+	); err != nil {
+		return
+	}
+
+	return
+}
+
+// Загрузка секрета из источника, завершающие символы перевода строки удаляются.
+// Формат указания источника описан в loadSource.
+func loadSecret(source string) (ret []byte, err error) {
+	if ret, err = loadSource(source); err != nil {
+		return
+	}
+	ret = bytes.TrimRight(ret, "\r\n")
+
+	return
+}
+
+// Загрузка содержимого из источника.
+// Источник указывается в виде: "env:ИМЯ" - значение переменной окружения, "credential:ИМЯ" - файл из
+// директории $CREDENTIALS_DIRECTORY, передаваемой systemd (LoadCredential=), "file:путь" или просто путь - файл.
+// Если источник содержит PEM блок, источник является содержимым и возвращается как есть.
+func loadSource(source string) (ret []byte, err error) {
+	var (
+		dir string
+		ok  bool
+	)
+
+	switch {
+	case isPEM([]byte(source)):
+		ret = []byte(source)
+	case strings.HasPrefix(source, secretPrefixEnv):
+		source = strings.TrimPrefix(source, secretPrefixEnv)
+		if ret = []byte(os.Getenv(source)); len(ret) == 0 {
+			err = fmt.Errorf("%w Переменная окружения: %q.", Errors().SecretNotFound(), source)
+			return
+		}
+	case strings.HasPrefix(source, secretPrefixCredential):
+		source = strings.TrimPrefix(source, secretPrefixCredential)
+		if dir, ok = os.LookupEnv(envCredentialsDir); !ok || dir == "" {
+			err = Errors().CredentialsDirectory()
+			return
+		}
+		if source == "" || source != path.Base(source) || source == "." || source == ".." {
+			err = fmt.Errorf("%w Учётные данные: %q.", Errors().SecretNotFound(), source)
+			return
+		}
+		if ret, err = os.ReadFile(path.Join(dir, source)); err != nil {
+			return
+		}
+	default:
+		if ret, err = os.ReadFile(strings.TrimPrefix(source, secretPrefixFile)); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Название источника для отображения в сообщениях об ошибках.
+// Содержимое PEM не отображается, чтобы не раскрывать секретный ключ.
+func sourceName(source string) (ret string) {
+	const inlinePEM = "inline:PEM"
+
+	switch isPEM([]byte(source)) {
+	case true:
+		ret = inlinePEM
+	default:
+		ret = source
+	}
+
+	return
+}
+
+// Загрузка публичного и секретного ключей из источников, формат указания источника описан в loadSource.
+// Секретный ключ может быть в формате PEM (PKCS#1, SEC1, PKCS#8), в том числе зашифрованный, либо находиться
+// в контейнере PKCS#12 (.p12, .pfx). Для контейнера PKCS#12 публичный ключ можно не указывать, в этом случае
+// используется сертификат и цепочка сертификатов из контейнера.
+func loadX509KeyPair(tlsPublicSource string, tlsPrivateSource string, passwordSource string) (
+	ret tls.Certificate,
+	err error,
+) {
+	var keyPEM, crtPEM []byte
+
+	if keyPEM, err = loadSource(tlsPrivateSource); err != nil {
+		return
+	}
+	switch isPEM(keyPEM) {
+	case true:
+		if keyPEM, err = decryptPrivateKeyPEM(keyPEM, passwordSource); err != nil {
+			return
+		}
+	default:
+		if keyPEM, crtPEM, err = decodePKCS12(keyPEM, passwordSource); err != nil {
+			return
+		}
+		if tlsPublicSource == "" || tlsPublicSource == tlsPrivateSource {
+			ret, err = tls.X509KeyPair(crtPEM, keyPEM)
+			return
+		}
+	}
+	if crtPEM, err = loadSource(tlsPublicSource); err != nil {
+		return
+	}
+	ret, err = tls.X509KeyPair(crtPEM, keyPEM)
+
+	return
+}
+
+// Возвращается истина, если данные содержат PEM блок.
+func isPEM(data []byte) bool { return bytes.Contains(data, []byte("-----BEGIN ")) }
+
+// Загрузка пароля из источника, если источник не указан, возвращается ошибка.
+func loadPassword(passwordSource string) (ret []byte, err error) {
+	if passwordSource == "" {
+		err = Errors().TLSPrivateKeyPasswordRequired()
+		return
+	}
+	ret, err = loadSecret(passwordSource)
+
+	return
+}
+
+// Расшифровка секретного ключа в PEM формате.
+// Если ключ не зашифрован, данные возвращаются без изменений. Расшифрованный ключ возвращается в
+// формате PKCS#8 PEM.
+func decryptPrivateKeyPEM(data []byte, passwordSource string) (ret []byte, err error) {
+	var (
+		block    *pem.Block
+		rest     []byte
+		der      []byte
+		key      any
+		password []byte
+	)
+
+	for rest = data; ; {
+		if block, rest = pem.Decode(rest); block == nil {
+			ret = data
+			return
+		}
+		if strings.HasSuffix(block.Type, pemPrivateKey) {
+			break
+		}
+	}
+	if block.Type != pemEncryptedPrivateKey && !x509.IsEncryptedPEMBlock(block) {
+		ret = data
+		return
+	}
+	if password, err = loadPassword(passwordSource); err != nil {
+		return
+	}
+	switch block.Type {
+	case pemEncryptedPrivateKey:
+		if der, err = decryptPKCS8(block.Bytes, password); err != nil {
+			return
+		}
+	default:
+		// Шифрование PEM по RFC 1423 (Proc-Type: 4,ENCRYPTED) поддерживается только для совместимости с ключами,
+		// созданными устаревшими версиями OpenSSL. Это шифрование небезопасно по своей конструкции: оно не
+		// проверяет целостность данных и уязвимо к атаке padding oracle, не верный пароль может быть не обнаружен
+		// при расшифровке, поэтому x509.DecryptPEMBlock объявлена устаревшей. Для новых ключей используется PKCS#8.
+		if der, err = x509.DecryptPEMBlock(block, password); err != nil {
+			err = fmt.Errorf("%w %s", Errors().TLSPrivateKeyDecrypt(), err)
+			return
+		}
+	}
+	if key, err = parsePrivateKeyDER(der); err != nil {
+		err = fmt.Errorf("%w %s", Errors().TLSPrivateKeyDecrypt(), err)
+		return
+	}
+	ret, err = marshalPrivateKeyPEM(key)
+
+	return
+}
+
+// Расшифровка секретного ключа PKCS#8 зашифрованного по схеме PBES2 (PBKDF2 + AES-CBC или 3DES-CBC).
+func decryptPKCS8(data []byte, password []byte) (ret []byte, err error) {
+	var (
+		epki   encryptedPrivateKeyInfo
+		params pbes2Params
+		kdf    pbkdf2Params
+		iv     []byte
+		key    []byte
+		prf    func() hash.Hash
+		block  cipher.Block
+		keyLen int
+	)
+
+	if _, err = asn1.Unmarshal(data, &epki); err != nil {
+		return
+	}
+	if !epki.Algorithm.Algorithm.Equal(oidPBES2) {
+		err = fmt.Errorf("%w %s", Errors().TLSPrivateKeyAlgorithm(), epki.Algorithm.Algorithm)
+		return
+	}
+	if _, err = asn1.Unmarshal(epki.Algorithm.Parameters.FullBytes, &params); err != nil {
+		return
+	}
+	if !params.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) {
+		err = fmt.Errorf("%w %s", Errors().TLSPrivateKeyAlgorithm(), params.KeyDerivationFunc.Algorithm)
+		return
+	}
+	if _, err = asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdf); err != nil {
+		return
+	}
+	switch {
+	case len(kdf.Prf.Algorithm) == 0, kdf.Prf.Algorithm.Equal(oidHmacWithSHA1):
+		prf = sha1.New
+	case kdf.Prf.Algorithm.Equal(oidHmacWithSHA224):
+		prf = sha256.New224
+	case kdf.Prf.Algorithm.Equal(oidHmacWithSHA256):
+		prf = sha256.New
+	case kdf.Prf.Algorithm.Equal(oidHmacWithSHA384):
+		prf = sha512.New384
+	case kdf.Prf.Algorithm.Equal(oidHmacWithSHA512):
+		prf = sha512.New
+	default:
+		err = fmt.Errorf("%w %s", Errors().TLSPrivateKeyAlgorithm(), kdf.Prf.Algorithm)
+		return
+	}
+	switch alg := params.EncryptionScheme.Algorithm; {
+	case alg.Equal(oidAES128CBC):
+		keyLen = 16
+	case alg.Equal(oidAES192CBC):
+		keyLen = 24
+	case alg.Equal(oidAES256CBC):
+		keyLen = 32
+	case alg.Equal(oidDESEDE3CBC):
+		keyLen = 24
+	default:
+		err = fmt.Errorf("%w %s", Errors().TLSPrivateKeyAlgorithm(), alg)
+		return
+	}
+	if _, err = asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil {
+		return
+	}
+	key = pbkdf2.Key(password, kdf.Salt, kdf.IterationCount, keyLen, prf)
+	switch params.EncryptionScheme.Algorithm.Equal(oidDESEDE3CBC) {
+	case true:
+		block, err = des.NewTripleDESCipher(key)
+	default:
+		block, err = aes.NewCipher(key)
+	}
+	if err != nil {
+		return
+	}
+	if len(iv) != block.BlockSize() || len(epki.EncryptedData)%block.BlockSize() != 0 {
+		err = Errors().TLSPrivateKeyDecrypt()
+		return
+	}
+	ret = make([]byte, len(epki.EncryptedData))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(ret, epki.EncryptedData)
+	if ret, err = unpadPKCS7(ret, block.BlockSize()); err != nil {
+		return
+	}
+
+	return
+}
+
+// Удаление выравнивания PKCS#7.
+// Неверное выравнивание, как правило, означает неверный пароль.
+func unpadPKCS7(data []byte, blockSize int) (ret []byte, err error) {
+	var (
+		pad int
+		n   int
+	)
+
+	if len(data) == 0 {
+		err = Errors().TLSPrivateKeyDecrypt()
+		return
+	}
+	if pad = int(data[len(data)-1]); pad == 0 || pad > blockSize || pad > len(data) {
+		err = Errors().TLSPrivateKeyDecrypt()
+		return
+	}
+	for n = len(data) - pad; n < len(data); n++ {
+		if int(data[n]) != pad {
+			err = Errors().TLSPrivateKeyDecrypt()
+			return
+		}
+	}
+	ret = data[:len(data)-pad]
+
+	return
+}
+
+// Разбор секретного ключа в формате DER: PKCS#8, PKCS#1 или SEC1.
+func parsePrivateKeyDER(der []byte) (ret any, err error) {
+	if ret, err = x509.ParsePKCS8PrivateKey(der); err == nil {
+		return
+	}
+	if ret, err = x509.ParsePKCS1PrivateKey(der); err == nil {
+		return
+	}
+	ret, err = x509.ParseECPrivateKey(der)
+
+	return
+}
+
+// Кодирование секретного ключа в формат PKCS#8 PEM.
+func marshalPrivateKeyPEM(key any) (ret []byte, err error) {
+	var der []byte
+
+	if der, err = x509.MarshalPKCS8PrivateKey(key); err != nil {
+		return
+	}
+	ret = pem.EncodeToMemory(&pem.Block{Type: pemPrivateKey, Bytes: der})
+
+	return
+}
+
+// Извлечение секретного ключа и цепочки сертификатов из контейнера PKCS#12.
+// Если источник пароля не указан, используется пустой пароль.
+func decodePKCS12(data []byte, passwordSource string) (keyPEM []byte, crtPEM []byte, err error) {
+	var (
+		password []byte
+		key      any
+		crt      *x509.Certificate
+		cas      []*x509.Certificate
+		n        int
+	)
+
+	if passwordSource != "" {
+		if password, err = loadSecret(passwordSource); err != nil {
+			return
+		}
+	}
+	if key, crt, cas, err = pkcs12.DecodeChain(data, string(password)); err != nil {
+		if passwordSource == "" && errors.Is(err, pkcs12.ErrIncorrectPassword) {
+			err = Errors().TLSPrivateKeyPasswordRequired()
+			return
+		}
+		err = fmt.Errorf("%w %s", Errors().TLSPrivateKeyDecrypt(), err)
+		return
+	}
+	if keyPEM, err = marshalPrivateKeyPEM(key); err != nil {
+		return
+	}
+	crtPEM = pem.EncodeToMemory(&pem.Block{Type: pemCertificate, Bytes: crt.Raw})
+	for n = range cas {
+		crtPEM = append(crtPEM, pem.EncodeToMemory(&pem.Block{Type: pemCertificate, Bytes: cas[n].Raw})...)
+	}
+
+	return
+}
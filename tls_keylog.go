@@ -0,0 +1,71 @@
+package net
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"sync"
+)
+
+const (
+	keyLogFileMode = 0600
+	keyLogWarning  = "# ВНИМАНИЕ!!! Включена запись секретов TLS сессий в файл %q (TLSKeyLogFile). " +
+		"Весь TLS трафик сервера может быть расшифрован. Не используйте в промышленной эксплуатации!\n"
+)
+
+// Запись секретов TLS сессий в файл, после закрытия файла секреты не записываются.
+type keyLogWriter struct {
+	lck *sync.Mutex // Защита от гонки.
+	fh  *os.File    // Файл записи секретов, nil после закрытия.
+}
+
+// Подключение записи секретов TLS сессий в файл в формате NSS key log, для расшифровки перехваченного
+// трафика, например, с помощью Wireshark. Файл закрывается при закрытии слушателя.
+// Предупреждение о включённой записи секретов выводится в стандартный поток ошибок процесса при открытии слушателя
+// и записывается в файл строкой комментария формата NSS key log.
+func (nut *impl) keyLogApply(conf *Configuration, tlsConfig *tls.Config, tln *tlsListener) (err error) {
+	var (
+		fh  *os.File
+		klw *keyLogWriter
+	)
+
+	if conf.TLSKeyLogFile == "" {
+		return
+	}
+	if fh, err = os.OpenFile(conf.TLSKeyLogFile, os.O_WRONLY|os.O_CREATE|os.O_APPEND, keyLogFileMode); err != nil {
+		return
+	}
+	if _, err = fmt.Fprintf(fh, keyLogWarning, conf.TLSKeyLogFile); err != nil {
+		_ = fh.Close()
+		return
+	}
+	_, _ = fmt.Fprintf(nut.stderr, keyLogWarning[len("# "):], conf.TLSKeyLogFile)
+	klw = &keyLogWriter{lck: new(sync.Mutex), fh: fh}
+	tlsConfig.KeyLogWriter = klw
+	tln.onClose = append(tln.onClose, klw.close)
+
+	return
+}
+
+// Write Запись секретов TLS сессии. После закрытия файла секреты отбрасываются.
+func (klw *keyLogWriter) Write(p []byte) (n int, err error) {
+	klw.lck.Lock()
+	defer klw.lck.Unlock()
+	if klw.fh == nil {
+		n = len(p)
+		return
+	}
+	n, err = klw.fh.Write(p)
+
+	return
+}
+
+// Закрытие файла записи секретов.
+func (klw *keyLogWriter) close() {
+	klw.lck.Lock()
+	defer klw.lck.Unlock()
+	if klw.fh != nil {
+		_ = klw.fh.Close()
+		klw.fh = nil
+	}
+}
@@ -0,0 +1,72 @@
+package net
+
+import (
+	"bytes"
+	"crypto/tls"
+	"net"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestKeyLogFile(t *testing.T) {
+	const keyLogLabel = "CLIENT_HANDSHAKE_TRAFFIC_SECRET"
+	var (
+		err       error
+		key       *tmpFile
+		crt       *tmpFile
+		conf      *Configuration
+		tlsConfig *tls.Config
+		lst       net.Listener
+		conn      *tls.Conn
+		buf       []byte
+		nut       Interface
+		stderr    *bytes.Buffer
+	)
+
+	stderr = new(bytes.Buffer)
+	key, crt = newTmpFile(getKeyEcdsa()), newTmpFile(getCrtEcdsa())
+	defer func() { key.Clean(); crt.Clean() }()
+	conf = &Configuration{
+		Host:             "127.0.0.1",
+		TLSPublicKeyPEM:  crt.Filename,
+		TLSPrivateKeyPEM: key.Filename,
+		TLSKeyLogFile:    path.Join(t.TempDir(), "keylog.txt"),
+	}
+	if tlsConfig, err = New().NewTLSConfig(conf); err != nil {
+		t.Fatalf("функция NewTLSConfig(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	nut = New()
+	nut.(*impl).stderr = stderr
+	if lst, _, err = nut.NewListenerTLS(conf, tlsConfig); err != nil {
+		t.Fatalf("функция NewListenerTLS(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	go func() {
+		if c, e := lst.Accept(); e == nil {
+			_ = c.(*tls.Conn).Handshake()
+			_ = c.Close()
+		}
+	}()
+	if conn, err = tls.Dial("tcp", lst.Addr().String(), &tls.Config{InsecureSkipVerify: true}); err != nil {
+		t.Fatalf("функция Dial(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	_ = conn.Close()
+	if err = lst.Close(); err != nil {
+		t.Errorf("функция Close(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	if buf, err = os.ReadFile(conf.TLSKeyLogFile); err != nil {
+		t.Fatalf("функция ReadFile(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	if !bytes.Contains(buf, []byte(keyLogLabel)) {
+		t.Errorf("файл TLSKeyLogFile не содержит %q", keyLogLabel)
+	}
+	if !bytes.HasPrefix(buf, []byte("# ")) {
+		t.Errorf("файл TLSKeyLogFile не содержит предупреждения")
+	}
+	if !bytes.Contains(stderr.Bytes(), []byte(conf.TLSKeyLogFile)) {
+		t.Errorf("предупреждение о записи секретов не выведено при открытии слушателя")
+	}
+	if tlsConfig.KeyLogWriter != nil {
+		t.Errorf("переданная TLS конфигурация изменена, KeyLogWriter: %v, ожидалось: %v", tlsConfig.KeyLogWriter, nil)
+	}
+}
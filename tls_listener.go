@@ -0,0 +1,50 @@
+package net
+
+import (
+	"crypto/tls"
+	"net"
+	"sync"
+)
+
+// Слушатель TLS соединений, освобождающий связанные с ним ресурсы при закрытии.
+// Для TLS конфигурации переключения соединений в режим TLS (STARTTLS) слушатель не указывается, объект хранит
+// только TLS конфигурацию и связанные с ней ресурсы.
+type tlsListener struct {
+	net.Listener
+	raw     net.Listener // Слушатель соединений без TLS.
+	config  *tls.Config  // TLS конфигурация слушателя с применёнными настройками конфигурации сервера.
+	onClose []func()     // Функции освобождения ресурсов, вызываемые при закрытии слушателя.
+	once    *sync.Once   // Однократное освобождение ресурсов.
+}
+
+// Конструктор объекта слушателя TLS соединений.
+func newTLSListener(l net.Listener, raw net.Listener) *tlsListener {
+	return &tlsListener{Listener: l, raw: raw, once: new(sync.Once)}
+}
+
+// Close Закрытие слушателя и освобождение связанных с ним ресурсов.
+func (tln *tlsListener) Close() (err error) {
+	if tln.Listener != nil {
+		err = tln.Listener.Close()
+	}
+	tln.once.Do(func() {
+		for n := range tln.onClose {
+			tln.onClose[n]()
+		}
+	})
+
+	return
+}
+
+// Применение настроек конфигурации сервера к TLS конфигурации и к слушателю TLS соединений.
+func (nut *impl) tlsListenerApply(conf *Configuration, tlsConfig *tls.Config, tln *tlsListener) (err error) {
+	tln.config = tlsConfig
+	if _, err = nut.sessionTicketKeysApply(conf, tlsConfig, tln); err != nil {
+		return
+	}
+	if err = nut.keyLogApply(conf, tlsConfig, tln); err != nil {
+		return
+	}
+
+	return
+}
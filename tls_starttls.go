@@ -0,0 +1,107 @@
+package net
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"time"
+)
+
+// StartTLS Переключение соединения, начатого без шифрования, в режим TLS (STARTTLS), например, для протоколов
+// SMTP, IMAP, LDAP. Рукопожатие выполняется сразу, с ограничением времени TLSHandshakeTimeout, ошибки рукопожатия
+// учитываются в TLSHandshakeFailures(), соединение с ошибкой рукопожатия закрывается.
+// Если tlsConfig не указан, используется TLS конфигурация слушателя сервера, запущенного в режиме TLS, или TLS
+// конфигурация, созданная на основе конфигурации сервера так же, как в NewListenerTLS, с ключами сессионных
+// билетов и записью секретов TLS сессий. Созданная конфигурация освобождается при остановке сервера.
+// Соединения с выполняемым рукопожатием закрываются при остановке сервера. Исходное соединение доступно через
+// NetConn() возвращённого соединения, статистика и учётные данные исходного соединения доступны через
+// возвращённое соединение, после переключения соединения чтение и запись должны выполняться только через
+// возвращённое соединение.
+func (nut *impl) StartTLS(conn net.Conn, tlsConfig *tls.Config) (ret *tls.Conn, err error) {
+	var (
+		conf    *Configuration
+		tln     *tlsListener
+		timeout time.Duration
+	)
+
+	if conn == nil {
+		err = net.ErrClosed
+		return
+	}
+	nut.lck.Lock()
+	if conf = nut.conf; tlsConfig == nil && nut.tlsStart != nil {
+		tlsConfig = nut.tlsStart.config
+	}
+	nut.lck.Unlock()
+	if conf == nil {
+		err = Errors().NoConfiguration()
+		return
+	}
+	// Загрузка ключей выполняется без блокировки, чтобы не блокировать остановку сервера и другие соединения.
+	if tlsConfig == nil {
+		if tln, err = nut.startTLSListener(conf); err != nil {
+			return
+		}
+	}
+	nut.lck.Lock()
+	if tln != nil {
+		// Конфигурация могла быть создана одновременно другим соединением, используется сохранённая первой.
+		if nut.tlsStart == nil {
+			nut.tlsStart, tln = tln, nil
+		}
+		tlsConfig = nut.tlsStart.config
+	}
+	timeout = conf.TLSHandshakeTimeout
+	if nut.tlsStartConns == nil {
+		nut.tlsStartConns = make(map[net.Conn]struct{})
+	}
+	nut.tlsStartConns[conn] = struct{}{}
+	nut.lck.Unlock()
+	if tln != nil {
+		_ = tln.Close()
+	}
+	defer func() {
+		nut.lck.Lock()
+		delete(nut.tlsStartConns, conn)
+		nut.lck.Unlock()
+	}()
+	ret, err = serverHandshake(conn, tlsConfig, timeout, nut.tlsFailures)
+
+	return
+}
+
+// Создание TLS конфигурации для переключения соединений в режим TLS. Конфигурация сохраняется в nut.tlsStart
+// вызывающей функцией и используется до остановки сервера.
+func (nut *impl) startTLSListener(conf *Configuration) (ret *tlsListener, err error) {
+	const errTemplate = "публичный ключ %q, секретный ключ %q, ошибка: %s"
+	var tlsConfig *tls.Config
+
+	if tlsConfig, err = nut.NewTLSConfig(conf); err != nil {
+		err = fmt.Errorf(errTemplate, sourceName(conf.TLSPublicKeyPEM), sourceName(conf.TLSPrivateKeyPEM), err)
+		return
+	}
+	ret = newTLSListener(nil, nil)
+	if err = nut.tlsListenerApply(conf, tlsConfig, ret); err != nil {
+		_ = ret.Close()
+		ret = nil
+		return
+	}
+
+	return
+}
+
+// Сброс TLS конфигурации переключения соединений в режим TLS: закрытие соединений с выполняемым рукопожатием и
+// освобождение ресурсов созданной TLS конфигурации. Слушатель сервера закрывается при остановке сервера.
+// Вызывается при захваченной блокировке nut.lck.
+func (nut *impl) startTLSReset() {
+	var conn net.Conn
+
+	for conn = range nut.tlsStartConns {
+		_ = conn.Close()
+	}
+	nut.tlsStartConns = nil
+	if nut.tlsStart != nil && nut.tlsStart.Listener == nil {
+		_ = nut.tlsStart.Close()
+	}
+	nut.tlsStart = nil
+}
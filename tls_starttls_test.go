@@ -0,0 +1,171 @@
+package net
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Обработчик текстового протокола с командой STARTTLS, после переключения в режим TLS возвращает полученные данные.
+func testStartTLSHandler(nut *Interface, errs chan error) HandlerFn {
+	return func(ltn net.Listener) (err error) {
+		var (
+			conn    net.Conn
+			tlsConn *tls.Conn
+			line    string
+		)
+
+		for {
+			if conn, err = ltn.Accept(); err != nil {
+				return
+			}
+			if line, err = bufio.NewReader(conn).ReadString('\n'); err != nil || line != "STARTTLS\r\n" {
+				_ = conn.Close()
+				continue
+			}
+			_, _ = conn.Write([]byte("OK\r\n"))
+			if tlsConn, err = (*nut).StartTLS(conn, nil); err != nil {
+				errs <- err
+				continue
+			}
+			_, err = io.Copy(tlsConn, tlsConn)
+			_ = tlsConn.Close()
+			errs <- err
+		}
+	}
+}
+
+func TestStartTLS(t *testing.T) {
+	var (
+		err     error
+		key     *tmpFile
+		crt     *tmpFile
+		nut     Interface
+		errs    chan error
+		conn    net.Conn
+		tlsConn *tls.Conn
+		buf     []byte
+		keyLog  string
+	)
+
+	key, crt = newTmpFile(getKeyEcdsa()), newTmpFile(getCrtEcdsa())
+	defer func() { key.Clean(); crt.Clean() }()
+	errs = make(chan error, 1)
+	nut = New()
+	nut.(*impl).stderr = io.Discard
+	nut.Handler(testStartTLSHandler(&nut, errs))
+	keyLog = filepath.Join(t.TempDir(), "keylog.txt")
+	if err = nut.ListenAndServeWithConfig(&Configuration{
+		Host:             "127.0.0.1",
+		TLSPublicKeyPEM:  crt.Filename,
+		TLSPrivateKeyPEM: key.Filename,
+		TLSKeyLogFile:    keyLog,
+	}).Error(); err != nil {
+		t.Fatalf("функция ListenAndServeWithConfig(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer nut.Stop()
+	// Успешное переключение в режим TLS.
+	conn = testStartTLSDial(t, nut)
+	tlsConn = tls.Client(conn, &tls.Config{InsecureSkipVerify: true})
+	if _, err = tlsConn.Write([]byte("ping")); err != nil {
+		t.Fatalf("функция Write(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	_ = tlsConn.CloseWrite()
+	if buf, err = io.ReadAll(tlsConn); err != nil || string(buf) != "ping" {
+		t.Errorf("функция ReadAll(), вернулось: %q, ошибка: %v, ожидалось: %q, %v", buf, err, "ping", nil)
+	}
+	_ = tlsConn.Close()
+	if err = <-errs; err != nil {
+		t.Errorf("функция StartTLS(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	// Настройки TLS конфигурации сервера применяются так же, как для слушателя TLS.
+	if buf, err = os.ReadFile(keyLog); err != nil || !bytes.Contains(buf, []byte("CLIENT_HANDSHAKE_TRAFFIC_SECRET")) {
+		t.Errorf("файл TLSKeyLogFile не содержит секретов TLS сессии, ошибка: %v", err)
+	}
+	// Клиент продолжает передачу без шифрования.
+	conn = testStartTLSDial(t, nut)
+	_, _ = conn.Write([]byte("GET / HTTP/1.0\r\n\r\n"))
+	if err = <-errs; err == nil {
+		t.Errorf("функция StartTLS(), ошибка: %v, ожидалась ошибка", err)
+	}
+	_ = conn.Close()
+	if n := nut.TLSHandshakeFailures()[handshakeFailureNotTLS]; n != 1 {
+		t.Errorf("функция TLSHandshakeFailures(), вернулось: %d, ожидалось: %d", n, 1)
+	}
+	// Остановка сервера освобождает TLS конфигурацию.
+	nut.Stop()
+	if nut.(*impl).tlsStart != nil {
+		t.Errorf("TLS конфигурация STARTTLS не освобождена после остановки сервера")
+	}
+}
+
+// Сервер, запущенный в режиме TLS, использует для STARTTLS TLS конфигурацию своего слушателя.
+func TestStartTLSListenerConfig(t *testing.T) {
+	var (
+		err       error
+		key       *tmpFile
+		crt       *tmpFile
+		nut       Interface
+		tlsConfig *tls.Config
+	)
+
+	key, crt = newTmpFile(getKeyEcdsa()), newTmpFile(getCrtEcdsa())
+	defer func() { key.Clean(); crt.Clean() }()
+	nut = New().Handler(testTcpHandler)
+	if err = nut.ListenAndServeTLSWithConfig(&Configuration{
+		Host:             "127.0.0.1",
+		TLSPublicKeyPEM:  crt.Filename,
+		TLSPrivateKeyPEM: key.Filename,
+	}, nil).Error(); err != nil {
+		t.Fatalf("функция ListenAndServeTLSWithConfig(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer nut.Stop()
+	nut.(*impl).lck.Lock()
+	if nut.(*impl).tlsStart != nil {
+		tlsConfig = nut.(*impl).tlsStart.config
+	}
+	nut.(*impl).lck.Unlock()
+	if tlsConfig == nil || tlsConfig != nut.(*impl).listener.Tcp().(*tlsListener).config {
+		t.Errorf("TLS конфигурация STARTTLS не является TLS конфигурацией слушателя сервера")
+	}
+}
+
+func TestStartTLSNoConfiguration(t *testing.T) {
+	var (
+		err    error
+		client net.Conn
+		server net.Conn
+	)
+
+	client, server = net.Pipe()
+	defer func() { _ = client.Close(); _ = server.Close() }()
+	if _, err = New().StartTLS(server, nil); !errors.Is(err, Errors().NoConfiguration()) {
+		t.Errorf("функция StartTLS(), ошибка: %v, ожидалось: %v", err, Errors().NoConfiguration())
+	}
+}
+
+// Подключение к серверу и отправка команды STARTTLS.
+func testStartTLSDial(t *testing.T, nut Interface) (ret net.Conn) {
+	var (
+		err  error
+		line string
+	)
+
+	if ret, err = net.Dial("tcp", nut.(*impl).listener.Addr().String()); err != nil {
+		t.Fatalf("функция Dial(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	if _, err = ret.Write([]byte("STARTTLS\r\n")); err != nil {
+		t.Fatalf("функция Write(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	if line, err = bufio.NewReader(ret).ReadString('\n'); err != nil || line != "OK\r\n" {
+		t.Fatalf("ответ на STARTTLS: %q, ошибка: %v, ожидалось: %q, %v", line, err, "OK\r\n", nil)
+	}
+
+	return
+}
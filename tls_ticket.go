@@ -0,0 +1,172 @@
+package net
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	sessionTicketKeyLength  = 32 // Размер ключа сессионных билетов TLS.
+	sessionTicketKeysMaxGen = 3  // Количество хранимых ключей при самостоятельной генерации ключей.
+)
+
+// Ключи сессионных билетов TLS с ротацией по расписанию.
+type sessionTicketKeys struct {
+	lck      *sync.Mutex   // Защита от гонки.
+	source   string        // Источник ключей, если не указан, ключи создаются процессом.
+	rotation time.Duration // Интервал ротации ключей.
+	keys     [][32]byte    // Текущие ключи, первый ключ используется для шифрования.
+	done     chan struct{} // Канал остановки ротации.
+	once     *sync.Once    // Однократная остановка ротации.
+}
+
+// Возвращается истина, если в конфигурации указаны настройки ключей сессионных билетов TLS.
+func isSessionTicketKeys(conf *Configuration) bool {
+	return conf.TLSSessionTicketKeyFile != "" || conf.TLSSessionTicketKeyRotation > 0
+}
+
+// Конструктор объекта ключей сессионных билетов TLS.
+func newSessionTicketKeys(conf *Configuration) *sessionTicketKeys {
+	return &sessionTicketKeys{
+		lck:      new(sync.Mutex),
+		source:   conf.TLSSessionTicketKeyFile,
+		rotation: conf.TLSSessionTicketKeyRotation,
+		done:     make(chan struct{}),
+		once:     new(sync.Once),
+	}
+}
+
+// Применение ключей сессионных билетов TLS к TLS конфигурации.
+// Если указан интервал ротации, запускается ротация ключей, которая останавливается при закрытии слушателя.
+func (nut *impl) sessionTicketKeysApply(conf *Configuration, tlsConfig *tls.Config, tln *tlsListener) (
+	stk *sessionTicketKeys,
+	err error,
+) {
+	if !isSessionTicketKeys(conf) {
+		return
+	}
+	stk = newSessionTicketKeys(conf)
+	if err = stk.update(); err != nil {
+		return
+	}
+	tlsConfig.SetSessionTicketKeys(stk.current())
+	if stk.rotation <= 0 {
+		return
+	}
+	go stk.rotate(tlsConfig)
+	tln.onClose = append(tln.onClose, stk.stop)
+
+	return
+}
+
+// Обновление ключей.
+// Если указан источник, ключи загружаются из источника, иначе создаётся новый ключ, а предыдущие ключи
+// сохраняются для расшифровки ранее выданных билетов.
+func (stk *sessionTicketKeys) update() (err error) {
+	var (
+		buf  []byte
+		key  [32]byte
+		keys [][32]byte
+	)
+
+	if stk.source != "" {
+		if buf, err = loadSource(stk.source); err != nil {
+			return
+		}
+		if keys, err = parseSessionTicketKeys(buf); err != nil {
+			return
+		}
+		stk.lck.Lock()
+		stk.keys = keys
+		stk.lck.Unlock()
+		return
+	}
+	if _, err = rand.Read(key[:]); err != nil {
+		return
+	}
+	stk.lck.Lock()
+	defer stk.lck.Unlock()
+	if keys = append([][32]byte{key}, stk.keys...); len(keys) > sessionTicketKeysMaxGen {
+		keys = keys[:sessionTicketKeysMaxGen]
+	}
+	stk.keys = keys
+
+	return
+}
+
+// Текущие ключи.
+func (stk *sessionTicketKeys) current() [][32]byte {
+	stk.lck.Lock()
+	defer stk.lck.Unlock()
+	return stk.keys
+}
+
+// Ротация ключей по расписанию, до остановки.
+// При ошибке загрузки ключей продолжают использоваться ранее загруженные ключи.
+func (stk *sessionTicketKeys) rotate(tlsConfig *tls.Config) {
+	var tic *time.Ticker
+
+	tic = time.NewTicker(stk.rotation)
+	defer tic.Stop()
+	for {
+		select {
+		case <-stk.done:
+			return
+		case <-tic.C:
+			if err := stk.update(); err == nil {
+				tlsConfig.SetSessionTicketKeys(stk.current())
+			}
+		}
+	}
+}
+
+// Остановка ротации ключей.
+func (stk *sessionTicketKeys) stop() { stk.once.Do(func() { close(stk.done) }) }
+
+// Разбор ключей сессионных билетов TLS.
+// Ключи указываются по одному в строке, в кодировке base64 или hex, строки начинающиеся с # игнорируются.
+// Если данные не являются текстом, данные рассматриваются как последовательность ключей по 32 байта.
+// Первый ключ используется для шифрования новых билетов, остальные только для расшифровки.
+func parseSessionTicketKeys(data []byte) (ret [][32]byte, err error) {
+	var (
+		scn  *bufio.Scanner
+		line string
+		buf  []byte
+		key  [32]byte
+	)
+
+	scn = bufio.NewScanner(bytes.NewReader(data))
+	for scn.Scan() {
+		if line = strings.TrimSpace(scn.Text()); line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if buf, err = hex.DecodeString(line); err != nil {
+			buf, err = base64.StdEncoding.DecodeString(line)
+		}
+		if err != nil || len(buf) != sessionTicketKeyLength {
+			ret = nil
+			break
+		}
+		copy(key[:], buf)
+		ret = append(ret, key)
+	}
+	if len(ret) == 0 && len(data) > 0 && len(data)%sessionTicketKeyLength == 0 {
+		for buf = data; len(buf) > 0; buf = buf[sessionTicketKeyLength:] {
+			copy(key[:], buf[:sessionTicketKeyLength])
+			ret = append(ret, key)
+		}
+	}
+	if err = nil; len(ret) == 0 {
+		err = Errors().TLSSessionTicketKey()
+		return
+	}
+
+	return
+}
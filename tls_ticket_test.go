@@ -0,0 +1,180 @@
+package net
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestParseSessionTicketKeys(t *testing.T) {
+	var (
+		err  error
+		k1   []byte
+		k2   []byte
+		keys [][32]byte
+	)
+
+	k1, k2 = bytes.Repeat([]byte{1}, sessionTicketKeyLength), bytes.Repeat([]byte{2}, sessionTicketKeyLength)
+	keys, err = parseSessionTicketKeys([]byte(
+		"# ключи\n" + hex.EncodeToString(k1) + "\n\n" + base64.StdEncoding.EncodeToString(k2) + "\n",
+	))
+	if err != nil || len(keys) != 2 || !bytes.Equal(keys[0][:], k1) || !bytes.Equal(keys[1][:], k2) {
+		t.Errorf("функция parseSessionTicketKeys(), ключей: %d, ошибка: %v, ожидалось: %d, %v", len(keys), err, 2, nil)
+	}
+	if keys, err = parseSessionTicketKeys(append(k2, k1...)); err != nil || len(keys) != 2 {
+		t.Errorf("функция parseSessionTicketKeys(), ключей: %d, ошибка: %v, ожидалось: %d, %v", len(keys), err, 2, nil)
+	}
+	if !bytes.Equal(keys[0][:], k2) {
+		t.Errorf("функция parseSessionTicketKeys(), не верный порядок ключей")
+	}
+	if _, err = parseSessionTicketKeys([]byte("abcd\n")); !errors.Is(err, Errors().TLSSessionTicketKey()) {
+		t.Errorf("функция parseSessionTicketKeys(), ошибка: %v, ожидалось: %v", err, Errors().TLSSessionTicketKey())
+	}
+}
+
+// Проверка возобновления TLS сессии на другом сервере, использующем тот же файл ключей.
+func TestSessionTicketKeysShared(t *testing.T) {
+	var (
+		err   error
+		key   *tmpFile
+		crt   *tmpFile
+		keys  *tmpFile
+		conf  *Configuration
+		nut   [2]Interface
+		lst   net.Listener
+		cache tls.ClientSessionCache
+		n     int
+	)
+
+	key, crt = newTmpFile(getKeyEcdsa()), newTmpFile(getCrtEcdsa())
+	keys = newTmpFile([]byte(hex.EncodeToString(bytes.Repeat([]byte{7}, sessionTicketKeyLength))))
+	defer func() { key.Clean(); crt.Clean(); keys.Clean() }()
+	for n = range nut {
+		conf = &Configuration{
+			Host:                    "127.0.0.1",
+			Port:                    uint16(18091 + n),
+			TLSPublicKeyPEM:         crt.Filename,
+			TLSPrivateKeyPEM:        key.Filename,
+			TLSSessionTicketKeyFile: keys.Filename,
+		}
+		nut[n] = New().Handler(testTcpHandler)
+		if lst, _, err = nut[n].NewListenerTLS(conf, nil); err != nil {
+			t.Fatalf("функция NewListenerTLS(), ошибка: %v, ожидалось: %v", err, nil)
+		}
+		if err = nut[n].Serve(lst).Error(); err != nil {
+			t.Fatalf("функция Serve(), ошибка: %v, ожидалось: %v", err, nil)
+		}
+		defer nut[n].Stop()
+	}
+	cache = tls.NewLRUClientSessionCache(1)
+	for n = range nut {
+		if resumed := testSessionTicketDial(t, nut[n].(*impl).listener.Addr().String(), cache); resumed != (n > 0) {
+			t.Errorf("возобновление TLS сессии: %t, ожидалось: %t", resumed, n > 0)
+		}
+	}
+}
+
+// Ключи сессионных билетов не применяются к переданной TLS конфигурации, используемой другими слушателями.
+func TestSessionTicketKeysConfigNotShared(t *testing.T) {
+	var (
+		err       error
+		key       *tmpFile
+		crt       *tmpFile
+		keys      *tmpFile
+		tlsConfig *tls.Config
+		confs     [2]*Configuration
+		nut       [2]Interface
+		lst       net.Listener
+		cache     tls.ClientSessionCache
+		n         int
+	)
+
+	key, crt = newTmpFile(getKeyEcdsa()), newTmpFile(getCrtEcdsa())
+	keys = newTmpFile([]byte(hex.EncodeToString(bytes.Repeat([]byte{7}, sessionTicketKeyLength))))
+	defer func() { key.Clean(); crt.Clean(); keys.Clean() }()
+	confs[0] = &Configuration{Host: "127.0.0.1", TLSSessionTicketKeyFile: keys.Filename}
+	confs[1] = &Configuration{Host: "127.0.0.1"}
+	if tlsConfig, err = New().NewTLSConfigDefault(crt.Filename, key.Filename); err != nil {
+		t.Fatalf("функция NewTLSConfigDefault(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	for n = range nut {
+		nut[n] = New().Handler(testTcpHandler)
+		if lst, _, err = nut[n].NewListenerTLS(confs[n], tlsConfig); err != nil {
+			t.Fatalf("функция NewListenerTLS(), ошибка: %v, ожидалось: %v", err, nil)
+		}
+		if err = nut[n].Serve(lst).Error(); err != nil {
+			t.Fatalf("функция Serve(), ошибка: %v, ожидалось: %v", err, nil)
+		}
+		defer nut[n].Stop()
+	}
+	cache = tls.NewLRUClientSessionCache(1)
+	for n = range nut {
+		if resumed := testSessionTicketDial(t, nut[n].(*impl).listener.Addr().String(), cache); resumed {
+			t.Errorf("возобновление TLS сессии: %t, ожидалось: %t", resumed, false)
+		}
+	}
+}
+
+// Подключение к TLS серверу, возвращается истина, если TLS сессия была возобновлена.
+func testSessionTicketDial(t *testing.T, addr string, cache tls.ClientSessionCache) (ret bool) {
+	var (
+		err  error
+		conn *tls.Conn
+	)
+
+	if conn, err = tls.Dial("tcp", addr, &tls.Config{
+		InsecureSkipVerify: true,
+		ClientSessionCache: cache,
+	}); err != nil {
+		t.Fatalf("функция Dial(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer func() { _ = conn.Close() }()
+	if _, err = conn.Write([]byte("ticket")); err != nil {
+		t.Fatalf("функция Write(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	if _, err = io.ReadAll(conn); err != nil {
+		t.Fatalf("функция ReadAll(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	ret = conn.ConnectionState().DidResume
+
+	return
+}
+
+// Проверка ротации самостоятельно создаваемых ключей.
+func TestSessionTicketKeysRotation(t *testing.T) {
+	const rotation = time.Millisecond * 10
+	var (
+		err error
+		stk *sessionTicketKeys
+		lst net.Listener
+		tln *tlsListener
+	)
+
+	if lst, err = net.Listen("tcp", "127.0.0.1:0"); err != nil {
+		t.Fatalf("функция Listen(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	tln = newTLSListener(lst, lst)
+	if stk, err = New().(*impl).sessionTicketKeysApply(
+		&Configuration{TLSSessionTicketKeyRotation: rotation}, tlsConfigDefault(), tln,
+	); err != nil {
+		t.Fatalf("функция sessionTicketKeysApply(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	time.Sleep(rotation * 5)
+	if err = tln.Close(); err != nil {
+		t.Errorf("функция Close(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	if n := len(stk.current()); n < 2 || n > sessionTicketKeysMaxGen {
+		t.Errorf("ротация ключей, ключей: %d, ожидалось от %d до %d", n, 2, sessionTicketKeysMaxGen)
+	}
+	select {
+	case <-stk.done:
+	default:
+		t.Errorf("ротация ключей не остановлена при закрытии слушателя")
+	}
+}
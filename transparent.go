@@ -0,0 +1,57 @@
+package net
+
+import (
+	"fmt"
+	"net"
+)
+
+// OriginalDestination Исходный адрес назначения принятого TCP соединения.
+// Для соединений, перенаправленных правилами REDIRECT или DNAT (iptables, nftables), адрес определяется опцией
+// SO_ORIGINAL_DST. Для остальных соединений, в том числе принятых в режиме Transparent (TPROXY), возвращается
+// локальный адрес соединения, который совпадает с исходным адресом назначения. Соединение может быть обёрнуто в
+// TLS, прокси-протокол или соединение мультиплексора, адрес определяется по исходному TCP соединению.
+func OriginalDestination(conn net.Conn) (ret net.Addr, err error) {
+	for ; conn != nil; conn = unwrapConn(conn) {
+		if tcp, ok := conn.(*net.TCPConn); ok {
+			if ret = originalDestination(tcp); ret == nil {
+				ret = tcp.LocalAddr()
+			}
+			return
+		}
+	}
+	err = Errors().OriginalDestination()
+
+	return
+}
+
+// ReadFromOriginalDestination Чтение UDP пакета с определением исходного адреса назначения пакета.
+// Адрес назначения определяется для сокетов, открытых в режиме Transparent (IP_RECVORIGDSTADDR), для остальных
+// сокетов возвращается локальный адрес сокета. Возвращается количество прочитанных байтов, адрес отправителя и
+// исходный адрес назначения пакета.
+func ReadFromOriginalDestination(conn net.PacketConn, b []byte) (n int, src net.Addr, dst net.Addr, err error) {
+	const oobSize = 128
+	var (
+		udp  *net.UDPConn
+		from *net.UDPAddr
+		oob  []byte
+		oobn int
+		ok   bool
+	)
+
+	if udp, ok = conn.(*net.UDPConn); !ok {
+		err = fmt.Errorf("%w: %T", Errors().OriginalDestination(), conn)
+		return
+	}
+	oob = make([]byte, oobSize)
+	if n, oobn, _, from, err = udp.ReadMsgUDP(b, oob); err != nil {
+		return
+	}
+	if src = from; oobn > 0 {
+		dst = originalDestinationOob(oob[:oobn])
+	}
+	if dst == nil {
+		dst = udp.LocalAddr()
+	}
+
+	return
+}
@@ -0,0 +1,110 @@
+//go:build linux
+
+package net
+
+import (
+	"encoding/binary"
+	"net"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// Опции SO_ORIGINAL_DST (linux/netfilter_ipv4.h) и IP6T_SO_ORIGINAL_DST (linux/netfilter_ipv6/ip6_tables.h).
+const (
+	soOriginalDst     = 80
+	ip6tSoOriginalDst = 80
+)
+
+// Исходный адрес назначения TCP соединения, перенаправленного правилами REDIRECT или DNAT.
+// Возвращается nil, если соединение не перенаправлено или адрес определить не удалось.
+func originalDestination(tcp *net.TCPConn) (ret net.Addr) {
+	var (
+		raw   syscall.RawConn
+		local *net.TCPAddr
+		ok    bool
+	)
+
+	if local, ok = tcp.LocalAddr().(*net.TCPAddr); !ok {
+		return
+	}
+	if raw, _ = tcp.SyscallConn(); raw == nil {
+		return
+	}
+	_ = raw.Control(func(fd uintptr) {
+		var (
+			ip   net.IP
+			port int
+		)
+
+		// IPv4 соединение, принятое сокетом IPv6 (адрес ::ffff:a.b.c.d), отслеживается netfilter как IPv4 соединение,
+		// поэтому опция выбирается по адресу соединения, а не по семейству сокета.
+		switch local.IP.To4() != nil {
+		case true:
+			mreq, err := unix.GetsockoptIPv6Mreq(int(fd), unix.SOL_IP, soOriginalDst)
+			if err != nil {
+				return
+			}
+			// Структура sockaddr_in: семейство (2 байта), порт (2 байта), адрес (4 байта).
+			ip, port = net.IP(mreq.Multiaddr[4:8]), int(binary.BigEndian.Uint16(mreq.Multiaddr[2:4]))
+		default:
+			info, err := unix.GetsockoptIPv6MTUInfo(int(fd), unix.SOL_IPV6, ip6tSoOriginalDst)
+			if err != nil {
+				return
+			}
+			ip, port = net.IP(info.Addr.Addr[:]), int(networkUint16(info.Addr.Port))
+		}
+		ret = &net.TCPAddr{IP: append(net.IP(nil), ip...), Port: port}
+	})
+
+	return
+}
+
+// Исходный адрес назначения UDP пакета из вспомогательных данных IP_ORIGDSTADDR, IPV6_ORIGDSTADDR.
+func originalDestinationOob(oob []byte) (ret net.Addr) {
+	var (
+		msgs []unix.SocketControlMessage
+		err  error
+		n    int
+	)
+
+	if msgs, err = unix.ParseSocketControlMessage(oob); err != nil {
+		return
+	}
+	for n = range msgs {
+		switch data := msgs[n].Data; {
+		case msgs[n].Header.Level == unix.SOL_IP && msgs[n].Header.Type == unix.IP_ORIGDSTADDR && len(data) >= 8:
+			// Структура sockaddr_in: семейство (2 байта), порт (2 байта), адрес (4 байта).
+			ret = &net.UDPAddr{
+				IP:   append(net.IP(nil), data[4:8]...),
+				Port: int(binary.BigEndian.Uint16(data[2:4])),
+			}
+			return
+		case msgs[n].Header.Level == unix.SOL_IPV6 && msgs[n].Header.Type == unix.IPV6_ORIGDSTADDR && len(data) >= 28:
+			// Структура sockaddr_in6: семейство (2 байта), порт (2 байта), flowinfo (4 байта), адрес (16 байтов),
+			// идентификатор зоны (4 байта).
+			addr := &net.UDPAddr{
+				IP:   append(net.IP(nil), data[8:24]...),
+				Port: int(binary.BigEndian.Uint16(data[2:4])),
+			}
+			if scope := binary.NativeEndian.Uint32(data[24:28]); scope != 0 {
+				if iface, e := net.InterfaceByIndex(int(scope)); e == nil {
+					addr.Zone = iface.Name
+				}
+			}
+			ret = addr
+			return
+		}
+	}
+
+	return
+}
+
+// Значение в сетевом порядке байтов, прочитанное из структуры ядра как число в порядке байтов процессора.
+func networkUint16(v uint16) uint16 {
+	var buf [2]byte
+
+	binary.NativeEndian.PutUint16(buf[:], v)
+
+	return binary.BigEndian.Uint16(buf[:])
+}
@@ -0,0 +1,217 @@
+//go:build linux
+
+package net
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Переменная окружения, указывающая, что тест выполняется в отдельном сетевом пространстве имён.
+const testNetnsEnv = "WEBNICE_NET_TEST_NETNS"
+
+// Проверка определения исходного адреса назначения соединений, перенаправленных правилом REDIRECT, и UDP пакетов,
+// перенаправленных правилом TPROXY. Тест требует прав root и утилит unshare, ip и nft, правила nftables создаются в
+// отдельном сетевом пространстве имён, тестовый бинарный файл перезапускается командой:
+//
+//	unshare -n <тест> -test.run '^TestOriginalDestinationRedirect$'
+func TestOriginalDestinationRedirect(t *testing.T) {
+	var (
+		err error
+		out []byte
+		cmd *exec.Cmd
+	)
+
+	if os.Getenv(testNetnsEnv) != "" {
+		testOriginalDestinationNetns(t)
+		return
+	}
+	if os.Getuid() != 0 {
+		t.Skip("тест требует прав root")
+	}
+	for _, name := range []string{"unshare", "ip", "nft"} {
+		if _, err = exec.LookPath(name); err != nil {
+			t.Skipf("утилита %q не найдена: %v", name, err)
+		}
+	}
+	cmd = exec.Command("unshare", "-n", os.Args[0], "-test.run", "^TestOriginalDestinationRedirect$", "-test.v")
+	cmd.Env = append(os.Environ(), testNetnsEnv+"=1")
+	if out, err = cmd.CombinedOutput(); err != nil {
+		t.Fatalf("тест в сетевом пространстве имён, ошибка: %v, вывод:\n%s", err, out)
+	}
+}
+
+// IPv4 соединение, принятое сокетом IPv6, без перенаправления возвращает локальный адрес соединения.
+func TestOriginalDestinationMapped(t *testing.T) {
+	var (
+		err  error
+		dual net.Listener
+		addr string
+	)
+
+	if dual, err = net.Listen("tcp", "[::]:0"); err != nil {
+		t.Skipf("функция Listen(), ошибка: %v", err)
+	}
+	defer func() { _ = dual.Close() }()
+	addr = fmt.Sprintf("127.0.0.1:%d", testPort(dual.Addr()))
+	if ret := testOriginalDestinationDial(t, dual, addr); ret != addr {
+		t.Errorf("функция OriginalDestination(), вернулось: %v, ожидалось: %v", ret, addr)
+	}
+}
+
+// Выполнение проверок в отдельном сетевом пространстве имён.
+func testOriginalDestinationNetns(t *testing.T) {
+	const (
+		tcpPort = 18199
+		udpPort = 18198
+	)
+	var (
+		err   error
+		tcp4  net.Listener
+		tcp6  net.Listener
+		dual  net.Listener
+		lpc   net.PacketConn
+		rules string
+	)
+
+	testCommand(t, nil, "ip", "link", "set", "lo", "up")
+	if tcp4, err = net.Listen("tcp4", "127.0.0.1:0"); err != nil {
+		t.Fatalf("функция Listen(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer func() { _ = tcp4.Close() }()
+	if tcp6, err = net.Listen("tcp6", "[::1]:0"); err != nil {
+		t.Fatalf("функция Listen(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer func() { _ = tcp6.Close() }()
+	// Сокет IPv6, принимающий IPv4 соединения с адресами вида ::ffff:a.b.c.d.
+	if dual, err = net.Listen("tcp", "[::]:0"); err != nil {
+		t.Fatalf("функция Listen(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer func() { _ = dual.Close() }()
+	if _, lpc, err = New().NewListener(&Configuration{Mode: netUdp, Host: "127.0.0.1", Transparent: true}); err != nil {
+		t.Fatalf("функция NewListener(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer func() { _ = lpc.Close() }()
+	rules = fmt.Sprintf(`
+table ip nat {
+	chain output {
+		type nat hook output priority -100;
+		ip daddr 127.0.0.1 tcp dport %[1]d redirect to :%[2]d
+		ip daddr 127.0.0.2 tcp dport %[1]d redirect to :%[3]d
+	}
+}
+table ip6 nat {
+	chain output {
+		type nat hook output priority -100;
+		tcp dport %[1]d redirect to :%[4]d
+	}
+}
+table ip mangle {
+	chain prerouting {
+		type filter hook prerouting priority -150;
+		ip daddr 127.0.0.3 udp dport %[5]d tproxy to 127.0.0.1:%[6]d accept
+	}
+}
+`, tcpPort, testPort(tcp4.Addr()), testPort(dual.Addr()), testPort(tcp6.Addr()), udpPort, testPort(lpc.LocalAddr()))
+	testCommand(t, strings.NewReader(rules), "nft", "-f", "-")
+	for _, test := range []struct {
+		ltn  net.Listener
+		addr string
+	}{
+		{tcp4, fmt.Sprintf("127.0.0.1:%d", tcpPort)},
+		{dual, fmt.Sprintf("127.0.0.2:%d", tcpPort)},
+		{tcp6, fmt.Sprintf("[::1]:%d", tcpPort)},
+	} {
+		if addr := testOriginalDestinationDial(t, test.ltn, test.addr); addr != test.addr {
+			t.Errorf("функция OriginalDestination(), вернулось: %v, ожидалось: %v", addr, test.addr)
+		}
+	}
+	testOriginalDestinationUdp(t, lpc, fmt.Sprintf("127.0.0.3:%d", udpPort))
+}
+
+// Подключение к адресу, перенаправленному на слушателя, возвращается исходный адрес назначения соединения.
+func testOriginalDestinationDial(t *testing.T, ltn net.Listener, addr string) (ret string) {
+	var (
+		err  error
+		conn net.Conn
+		srv  net.Conn
+		dst  net.Addr
+	)
+
+	if conn, err = net.DialTimeout("tcp", addr, time.Second); err != nil {
+		t.Fatalf("функция Dial(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer func() { _ = conn.Close() }()
+	if srv, err = ltn.Accept(); err != nil {
+		t.Fatalf("функция Accept(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer func() { _ = srv.Close() }()
+	if dst, err = OriginalDestination(srv); err != nil {
+		t.Fatalf("функция OriginalDestination(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	ret = dst.String()
+
+	return
+}
+
+// Отправка UDP пакета на адрес, перенаправленный правилом TPROXY на сокет в режиме Transparent.
+func testOriginalDestinationUdp(t *testing.T, lpc net.PacketConn, addr string) {
+	var (
+		err  error
+		conn net.Conn
+		buf  []byte
+		n    int
+		dst  net.Addr
+	)
+
+	if conn, err = net.Dial("udp", addr); err != nil {
+		t.Fatalf("функция Dial(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer func() { _ = conn.Close() }()
+	if _, err = conn.Write([]byte("ping")); err != nil {
+		t.Fatalf("функция Write(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	_ = lpc.SetReadDeadline(time.Now().Add(time.Second))
+	buf = make([]byte, 64)
+	if n, _, dst, err = ReadFromOriginalDestination(lpc, buf); err != nil {
+		t.Fatalf("функция ReadFromOriginalDestination(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	if string(buf[:n]) != "ping" || dst == nil || dst.String() != addr {
+		t.Errorf("функция ReadFromOriginalDestination(), вернулось: %q на %v, ожидалось: %q на %v",
+			buf[:n], dst, "ping", addr)
+	}
+}
+
+// Выполнение команды, при ошибке тест завершается.
+func testCommand(t *testing.T, stdin *strings.Reader, name string, args ...string) {
+	var (
+		err error
+		out []byte
+		cmd *exec.Cmd
+	)
+
+	cmd = exec.Command(name, args...)
+	if stdin != nil {
+		cmd.Stdin = stdin
+	}
+	if out, err = cmd.CombinedOutput(); err != nil {
+		t.Fatalf("команда %s %s, ошибка: %v, вывод:\n%s", name, strings.Join(args, " "), err, out)
+	}
+}
+
+// Порт адреса слушателя.
+func testPort(addr net.Addr) (ret int) {
+	switch a := addr.(type) {
+	case *net.TCPAddr:
+		ret = a.Port
+	case *net.UDPAddr:
+		ret = a.Port
+	}
+
+	return
+}
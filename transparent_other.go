@@ -0,0 +1,11 @@
+//go:build !linux
+
+package net
+
+import "net"
+
+// Определение исходного адреса назначения перенаправленного соединения поддерживается только в Linux.
+func originalDestination(_ *net.TCPConn) (ret net.Addr) { return }
+
+// Определение исходного адреса назначения UDP пакета поддерживается только в Linux.
+func originalDestinationOob(_ []byte) (ret net.Addr) { return }
@@ -0,0 +1,84 @@
+package net
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+// Соединение без перенаправления, исходный адрес назначения совпадает с локальным адресом соединения.
+func TestOriginalDestination(t *testing.T) {
+	var (
+		err    error
+		nut    Interface
+		conn   net.Conn
+		result chan net.Addr
+	)
+
+	result = make(chan net.Addr, 1)
+	nut = New().Handler(func(ltn net.Listener) (err error) {
+		var c net.Conn
+
+		for {
+			if c, err = ltn.Accept(); err != nil {
+				return
+			}
+			addr, _ := OriginalDestination(c)
+			result <- addr
+			_ = c.Close()
+		}
+	})
+	if err = nut.ListenAndServeWithConfig(&Configuration{Host: "127.0.0.1"}).Error(); err != nil {
+		t.Fatalf("функция ListenAndServeWithConfig(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer nut.Stop()
+	if conn, err = net.Dial(nut.Addr().Network(), nut.Addr().String()); err != nil {
+		t.Fatalf("функция Dial(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer func() { _ = conn.Close() }()
+	if addr := <-result; addr == nil || addr.String() != nut.Addr().String() {
+		t.Errorf("функция OriginalDestination(), вернулось: %v, ожидалось: %v", addr, nut.Addr())
+	}
+	c1, c2 := net.Pipe()
+	defer func() { _, _ = c1.Close(), c2.Close() }()
+	if _, err = OriginalDestination(c1); !errors.Is(err, Errors().OriginalDestination()) {
+		t.Errorf("функция OriginalDestination(), ошибка: %v, ожидалось: %v", err, Errors().OriginalDestination())
+	}
+}
+
+func TestReadFromOriginalDestination(t *testing.T) {
+	var (
+		err  error
+		lpc  net.PacketConn
+		conn net.Conn
+		buf  []byte
+		n    int
+		src  net.Addr
+		dst  net.Addr
+	)
+
+	if _, lpc, err = New().NewListener(&Configuration{Mode: netUdp, Host: "127.0.0.1", Transparent: true}); err != nil {
+		t.Skipf("функция NewListener(), режим Transparent, ошибка: %v", err)
+	}
+	defer func() { _ = lpc.Close() }()
+	if conn, err = net.Dial(lpc.LocalAddr().Network(), lpc.LocalAddr().String()); err != nil {
+		t.Fatalf("функция Dial(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer func() { _ = conn.Close() }()
+	if _, err = conn.Write([]byte("ping")); err != nil {
+		t.Fatalf("функция Write(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	_ = lpc.SetReadDeadline(time.Now().Add(time.Second))
+	buf = make([]byte, 64)
+	if n, src, dst, err = ReadFromOriginalDestination(lpc, buf); err != nil {
+		t.Fatalf("функция ReadFromOriginalDestination(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	if string(buf[:n]) != "ping" || src.String() != conn.LocalAddr().String() {
+		t.Errorf("функция ReadFromOriginalDestination(), вернулось: %q от %v, ожидалось: %q от %v",
+			buf[:n], src, "ping", conn.LocalAddr())
+	}
+	if dst == nil || dst.String() != lpc.LocalAddr().String() {
+		t.Errorf("функция ReadFromOriginalDestination(), адрес назначения: %v, ожидалось: %v", dst, lpc.LocalAddr())
+	}
+}
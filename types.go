@@ -1,10 +1,12 @@
 package net
 
 import (
+	"io"
 	"net"
 	"os"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 const (
@@ -23,20 +25,49 @@ const (
 
 const defaultSocketFileMode = 0666
 
+// Задержка повтора приёма соединения после ошибки нехватки ресурсов.
+const (
+	acceptRetryDelayMin = time.Millisecond * 5
+	acceptRetryDelayMax = time.Second
+)
+
+// Политики разрешения имени хоста, указанного в Host, в IP адрес для открытия порта.
+const (
+	// HostResolveFirst Используется первый адрес в порядке, возвращённом DNS резолвером.
+	HostResolveFirst = "first"
+
+	// HostResolveAll Порт открывается на всех адресах, в которые разрешается имя хоста.
+	HostResolveAll = "all"
+
+	// HostResolveIPv4 Используется первый IPv4 адрес.
+	HostResolveIPv4 = "ipv4"
+
+	// HostResolveIPv6 Используется первый IPv6 адрес.
+	HostResolveIPv6 = "ipv6"
+
+	// HostResolveSingle Имя хоста должно разрешаться ровно в один адрес, иначе возвращается ошибка.
+	HostResolveSingle = "single"
+)
+
 // Объект сущности, реализующий интерфейс Interface.
 type impl struct {
-	lck        *sync.Mutex                          // Защита от гонки.
-	err        error                                // Сохранение последней ошибки.
-	isRun      *atomic.Bool                         // Состояние выполнения сервера, =истина - запущен, =ложь - остановлен.
-	handler    HandlerFn                            // Основная функция TCP сервера.
-	handlerUdp HandlerUdpFn                         // Основная функция UDP сервера.
-	listener   *netListener                         // Слушатель сокета сервера содержащий либо UDP либо TCP соединение.
-	isShutdown *atomic.Bool                         // Флаг начала завершения работы сервера.
-	onShutdown chan struct{}                        // Канал передачи сигнала об окончании завершения работы сервера.
-	conf       *Configuration                       // Конфигурация сервера.
-	fnFl       func(*os.File) (net.Listener, error) // Функция net.FileListener, подменяемая при тестировании.
-	fnNf       func(uintptr, string) *os.File       // Функция os.NewFile, подменяемая при тестировании.
-	fnFc       func(*os.File) error                 // Функция закрытия файлового дескриптора, подменяемая при тестировании.
+	lck           *sync.Mutex                          // Защита от гонки.
+	err           error                                // Сохранение последней ошибки.
+	isRun         *atomic.Bool                         // Состояние выполнения сервера, =истина - запущен, =ложь - остановлен.
+	handler       HandlerFn                            // Основная функция TCP сервера.
+	handlerUdp    HandlerUdpFn                         // Основная функция UDP сервера.
+	listener      *netListener                         // Слушатель сокета сервера содержащий либо UDP либо TCP соединение.
+	isShutdown    *atomic.Bool                         // Флаг начала завершения работы сервера.
+	onShutdown    chan struct{}                        // Канал передачи сигнала об окончании завершения работы сервера.
+	conf          *Configuration                       // Конфигурация сервера.
+	fnFl          func(*os.File) (net.Listener, error) // Функция net.FileListener, подменяемая при тестировании.
+	fnNf          func(uintptr, string) *os.File       // Функция os.NewFile, подменяемая при тестировании.
+	fnFc          func(*os.File) error                 // Функция закрытия файлового дескриптора, подменяемая при тестировании.
+	tlsFailures   *handshakeFailures                   // Счётчики ошибок TLS рукопожатия по причинам.
+	tcpInfo       *tcpInfoMetrics                      // Метрики статистики TCP соединений.
+	tlsStart      *tlsListener                         // TLS конфигурация для переключения соединений в режим TLS (STARTTLS).
+	tlsStartConns map[net.Conn]struct{}                // Соединения с выполняемым рукопожатием STARTTLS.
+	stderr        io.Writer                            // Вывод предупреждений, подменяемый при тестировании.
 }
 
 // HandlerFn Описание типа функции TCP или сокет сервера.
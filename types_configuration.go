@@ -16,23 +16,79 @@ type Configuration struct {
 	Address string `yaml:"Address" json:"address"`
 
 	// Host IP адрес или имя хоста на котором поднимается сервер, можно указывать 0.0.0.0 для всех ip адресов.
+	// Так же можно указать имя сетевого интерфейса, например "eth0" или "wg0", тогда порт открывается на текущих
+	// адресах интерфейса, получаемых при открытии порта, выбор адреса выполняется согласно HostResolve.
+	// Имя сетевого интерфейса имеет приоритет перед именем хоста.
 	// Default value: "0.0.0.0"
 	Host string `yaml:"Host" json:"host" default-value:"0.0.0.0"`
 
+	// HostResolve Политика разрешения имени хоста или выбора адреса сетевого интерфейса, указанного в Host,
+	// возможные значения:
+	// first  - Используется первый адрес в порядке, возвращённом DNS резолвером или сетевым интерфейсом;
+	// all    - Порт открывается на всех адресах, в которые разрешается имя хоста или адресах интерфейса, кроме UDP;
+	// ipv4   - Используется первый IPv4 адрес;
+	// ipv6   - Используется первый IPv6 адрес;
+	// single - Имя хоста должно разрешаться ровно в один адрес, иначе сервер не запускается.
+	// Режимы tcp4, udp4 и tcp6, udp6 учитывают только адреса соответствующего типа. Если Host указан как IP адрес,
+	// в том числе IPv6 адрес с зоной, например "fe80::1%eth0", разрешение имени не выполняется, но адрес, тип
+	// которого не соответствует политике ipv4, ipv6 или режиму, является ошибкой.
+	// Default value: "first"
+	HostResolve string `yaml:"HostResolve" json:"host_resolve" default-value:"first"`
+
+	// BindToDevice Привязка сокета к сетевому интерфейсу, указанному в Host, опцией SO_BINDTODEVICE.
+	// Сервер принимает соединения только пришедшие через указанный интерфейс. Только для Linux, может требовать
+	// права CAP_NET_RAW.
+	// Default value: false
+	BindToDevice bool `yaml:"BindToDevice" json:"bind_to_device"`
+
 	// Port TCP/IP порт занимаемый сервером.
 	// Default value: 0
 	Port uint16 `yaml:"Port" json:"port" default-value:"-"`
 
 	// Socket Unix socket, systemd socket на котором поднимается сервер, только для unix-like операционных
 	// систем Linux, Unix, Mac.
+	// Имя сокета, начинающееся с "@", открывается в абстрактном пространстве имён Linux, такой сокет не создаёт файла
+	// в файловой системе, параметры SocketMode, SocketOwner, SocketGroup и SocketDirectoryMode для него не
+	// используются.
 	// Default value: ""
 	Socket string `yaml:"Socket" json:"socket" default-value:"-"`
 
 	// SocketMode Файловые разрешения доступа к юникс-сокету.
-	// Значение задаётся в восьмеричной системе счисления и не должно превышать 32 бита.
+	// Значение задаётся в восьмеричной системе счисления и не должно превышать 0777, при не верном значении сокет не
+	// открывается. Права доступа устанавливаются до того, как к сокету становится возможным подключиться.
 	// Default value: "0666"
 	SocketMode string `yaml:"SocketMode" json:"socket_mode" default-value:"0666"`
 
+	// SocketOwner Владелец юникс-сокета, имя пользователя или числовой идентификатор (UID).
+	// Для изменения владельца требуются права CAP_CHOWN.
+	// Default value: "" - владелец не изменяется
+	SocketOwner string `yaml:"SocketOwner" json:"socket_owner"`
+
+	// SocketGroup Группа юникс-сокета, имя группы или числовой идентификатор (GID), например группа веб сервера,
+	// подключающегося к сокету. Процесс может назначить группу, в которую входит пользователь процесса.
+	// Default value: "" - группа не изменяется
+	SocketGroup string `yaml:"SocketGroup" json:"socket_group"`
+
+	// SocketDirectoryMode Файловые разрешения доступа к директории юникс-сокета, создаваемой при открытии сокета,
+	// если директория не существует. Созданной директории так же назначаются SocketOwner и SocketGroup.
+	// Разрешения существующей директории не изменяются.
+	// Значение задаётся в восьмеричной системе счисления.
+	// Default value: "0755"
+	SocketDirectoryMode string `yaml:"SocketDirectoryMode" json:"socket_directory_mode" default-value:"0755"`
+
+	// SocketPeerUID Список идентификаторов пользователей, процессам которых разрешено подключение к юникс-сокету.
+	// Учётные данные процесса клиента (SO_PEERCRED) проверяются при приёме соединения, соединение принимается, если
+	// пользователь указан в SocketPeerUID или группа указана в SocketPeerGID, иначе соединение закрывается.
+	// Если оба списка пустые, проверка не выполняется. Для TCP/IP соединений не используется. Только для Linux, в
+	// других операционных системах указание списка является ошибкой открытия слушателя.
+	// Default value: []
+	SocketPeerUID []uint32 `yaml:"SocketPeerUID" json:"socket_peer_uid"`
+
+	// SocketPeerGID Список идентификаторов групп, процессам которых разрешено подключение к юникс-сокету.
+	// Проверяется только основная группа процесса клиента, дополнительные группы процесса не учитываются.
+	// Default value: []
+	SocketPeerGID []uint32 `yaml:"SocketPeerGID" json:"socket_peer_gid"`
+
 	// Mode Режим открытия сокета, возможные значения: tcp, tcp4, tcp6, unix, unixpacket, socket, systemd.
 	// udp, udp4, udp6 - Сервер поднимается на указанном Host:Port;
 	// tcp, tcp4, tcp6 - Сервер поднимается на указанном Host:Port;
@@ -46,17 +102,112 @@ type Configuration struct {
 	// Default value: "tcp"
 	Mode string `yaml:"Mode" json:"mode" default-value:"tcp"`
 
+	// Listen Список адресов, на которых одновременно поднимается сервер, для TCP/IP, unix сокетов и systemd.
+	// Если список указан, параметры Host, Port и Socket не используются для открытия адресов, а Mode определяет
+	// тип TCP/IP соединения (tcp, tcp4, tcp6). Формат адреса:
+	// host:port            - TCP/IP адрес, например "0.0.0.0:80" или "[::]:80";
+	// unix:путь или /путь  - Unix сокет, файловые разрешения задаются SocketMode;
+	// systemd:имя          - Все сокеты с указанным именем, переданные systemd.
+	// Так же допускается адрес в формате URL, кроме udp:// и tls://, например "tcp6://[::]:80".
+	// Адрес "0.0.0.0" открывается только для IPv4, адрес "::" только для IPv6 (IPV6_V6ONLY), поэтому
+	// "0.0.0.0:80" и "[::]:80" могут быть указаны одновременно.
+	// Все адреса открываются атомарно, при ошибке открытия любого адреса уже открытые адреса закрываются.
+	// Соединения всех адресов передаются в основную функцию сервера через один слушатель.
+	// Для UDP не используется.
+	// Default value: []
+	Listen []string `yaml:"Listen" json:"listen"`
+
 	// TLSPublicKeyPEM Путь и имя файла содержащего публичный ключ (сертификат) в PEM формате, включая CA
 	// сертификаты всех промежуточных центров сертификации, если ими подписан ключ.
+	// Вместо пути к файлу можно указать:
+	// PEM содержимое - Значение содержащее "-----BEGIN ", используется как есть;
+	// env:ИМЯ        - Содержимое загружается из переменной окружения;
+	// credential:ИМЯ - Содержимое загружается из файла учётных данных systemd, в директории
+	//                  $CREDENTIALS_DIRECTORY (LoadCredential=, LoadCredentialEncrypted=, SetCredential=).
 	// Применяется только для TCP соединений, для UDP не используется.
 	// Default value: ""
 	TLSPublicKeyPEM string `yaml:"TLSPublicKeyPEM" json:"tls_public_key_pem"`
 
 	// TLSPrivateKeyPEM Путь и имя файла содержащего секретный/приватный ключ в PEM формате.
+	// Ключ может быть зашифрован (PKCS#8 ENCRYPTED PRIVATE KEY или PEM с заголовком Proc-Type: 4,ENCRYPTED),
+	// либо может быть указан контейнер PKCS#12 (.p12, .pfx), в этом случае TLSPublicKeyPEM можно не указывать.
+	// Шифрование Proc-Type: 4,ENCRYPTED устарело и небезопасно, поддерживается только для совместимости, для новых
+	// ключей используйте PKCS#8.
+	// Вместо пути к файлу можно указать PEM содержимое, env:ИМЯ или credential:ИМЯ, так же как для
+	// TLSPublicKeyPEM.
 	// Применяется только для TCP соединений, для UDP не используется.
 	// Default value: ""
 	TLSPrivateKeyPEM string `yaml:"TLSPrivateKeyPEM" json:"tls_private_key_pem"`
 
+	// TLSPrivateKeyPassword Источник пароля зашифрованного секретного ключа или контейнера PKCS#12.
+	// Возможные значения:
+	// file:путь или путь - Пароль загружается из файла;
+	// env:ИМЯ            - Пароль загружается из переменной окружения;
+	// credential:ИМЯ     - Пароль загружается из файла учётных данных systemd, в директории $CREDENTIALS_DIRECTORY
+	//                      (LoadCredential=, LoadCredentialEncrypted=, SetCredential=).
+	// Завершающие символы перевода строки в пароле удаляются.
+	// Default value: ""
+	TLSPrivateKeyPassword string `yaml:"TLSPrivateKeyPassword" json:"tls_private_key_password"`
+
+	// DevSelfSigned Режим разработки, создание самоподписанного сертификата.
+	// Если сервер запускается в режиме TLS, а TLSPublicKeyPEM и TLSPrivateKeyPEM не указаны, в памяти создаётся
+	// центр сертификации и подписанный им сертификат (ECDSA) для Host и localhost.
+	// Не используйте в промышленной эксплуатации.
+	// Default value: false
+	DevSelfSigned bool `yaml:"DevSelfSigned" json:"dev_self_signed"`
+
+	// DevSelfSignedCAFile Путь и имя файла, в который сохраняется публичный ключ (сертификат) созданного центра
+	// сертификации в PEM формате, для добавления в список доверенных сертификатов клиента. Секретный ключ центра
+	// сертификации сохраняется рядом, в файл с окончанием ".key", и центр сертификации используется повторно при
+	// следующих запусках сервера, пока не истекает срок его действия.
+	// Используется только при включённом DevSelfSigned.
+	// Default value: "" - не сохраняется
+	DevSelfSignedCAFile string `yaml:"DevSelfSignedCAFile" json:"dev_self_signed_ca_file"`
+
+	// TLSSessionTicketKeyFile Путь и имя файла содержащего ключи сессионных билетов TLS (session ticket keys).
+	// Ключи указываются по одному в строке, в кодировке base64 или hex, длина каждого ключа 32 байта, либо
+	// файл содержит ключи в двоичном виде, последовательно по 32 байта. Первый ключ используется для шифрования
+	// новых билетов, остальные только для расшифровки ранее выданных билетов.
+	// Один и тот же файл может использоваться несколькими процессами за одним балансировщиком нагрузки, что
+	// обеспечивает возобновление TLS сессий на любом из процессов. Вместо пути к файлу можно указать env:ИМЯ
+	// или credential:ИМЯ, так же как для TLSPublicKeyPEM.
+	// Если не указан, используются случайные ключи, создаваемые каждым процессом.
+	// Default value: ""
+	TLSSessionTicketKeyFile string `yaml:"TLSSessionTicketKeyFile" json:"tls_session_ticket_key_file"`
+
+	// TLSSessionTicketKeyRotation Интервал ротации ключей сессионных билетов TLS.
+	// Если указан TLSSessionTicketKeyFile, с этим интервалом ключи повторно загружаются из файла, ротацию ключей
+	// в файле выполняет внешний процесс. Если файл не указан, процесс сам создаёт новый ключ с этим интервалом,
+	// сохраняя два предыдущих ключа для расшифровки ранее выданных билетов.
+	// Default value: 0s - ротация не выполняется
+	TLSSessionTicketKeyRotation time.Duration `yaml:"TLSSessionTicketKeyRotation" json:"tls_session_ticket_key_rotation"`
+
+	// TLSKeyLogFile Путь и имя файла, в который записываются секреты TLS сессий в формате NSS key log, для
+	// расшифровки перехваченного трафика при отладке, например, с помощью Wireshark.
+	// ВНИМАНИЕ!!! Позволяет расшифровать весь TLS трафик сервера, при открытии файла предупреждение выводится в
+	// стандартный поток ошибок процесса и записывается в файл строкой комментария.
+	// Не используйте в промышленной эксплуатации.
+	// Default value: "" - выключено
+	TLSKeyLogFile string `yaml:"TLSKeyLogFile" json:"tls_key_log_file"`
+
+	// TLSHandshakeEager Выполнение TLS рукопожатия до передачи соединения в основную функцию сервера.
+	// Соединения с ошибкой рукопожатия закрываются и не передаются в основную функцию сервера, ошибки
+	// учитываются в счётчиках по причинам, доступных через TLSHandshakeFailures(). Для переданных соединений
+	// согласованные ALPN и SNI доступны сразу, через ConnectionState().
+	// Default value: false - рукопожатие выполняется при первом чтении или записи в соединение
+	TLSHandshakeEager bool `yaml:"TLSHandshakeEager" json:"tls_handshake_eager"`
+
+	// TLSHandshakeTimeout Максимальное время ожидания завершения TLS рукопожатия.
+	// Время ожидания используется при включённом TLSHandshakeEager и в StartTLS.
+	// Default value: 0s - используется 10s
+	TLSHandshakeTimeout time.Duration `yaml:"TLSHandshakeTimeout" json:"tls_handshake_timeout"`
+
+	// TLSHandshakeConcurrency Максимальное количество одновременно выполняемых TLS рукопожатий при включённом
+	// TLSHandshakeEager. При достижении ограничения приём новых соединений приостанавливается до завершения
+	// выполняемых рукопожатий.
+	// Default value: 0 - используется 1024
+	TLSHandshakeConcurrency uint `yaml:"TLSHandshakeConcurrency" json:"tls_handshake_concurrency"`
+
 	// ProxyProtocol Включение прокси-протокола.
 	// Прокси-протокол позволяет серверу получать информацию о подключении клиента, передаваемую через
 	// прокси-серверы и средства балансировки нагрузки, такие как Nginx, HAProxy, Amazon Elastic Load
@@ -70,6 +221,113 @@ type Configuration struct {
 	// Время ожидание используется только при включённом прокси-протоколе.
 	// Default value: 0s - no timeout
 	ProxyProtocolReadHeaderTimeout time.Duration `yaml:"ProxyProtocolReadHeaderTimeout" json:"proxy_protocol_read_header_timeout"`
+
+	// KeepAliveDisable Отключение TCP keepalive для принятых соединений.
+	// Default value: false - keepalive включён, параметры выбираются средой выполнения Go
+	KeepAliveDisable bool `yaml:"KeepAliveDisable" json:"keep_alive_disable"`
+
+	// KeepAliveIdle Время простоя соединения до отправки первой keepalive пробы (TCP_KEEPIDLE).
+	// Если указан любой из параметров KeepAliveIdle, KeepAliveInterval, KeepAliveCount, параметры keepalive
+	// устанавливаются на сокет слушателя и наследуются принятыми соединениями. Только для Linux.
+	// Default value: 0s - значение по умолчанию
+	KeepAliveIdle time.Duration `yaml:"KeepAliveIdle" json:"keep_alive_idle"`
+
+	// KeepAliveInterval Интервал между keepalive пробами (TCP_KEEPINTVL). Только для Linux.
+	// Default value: 0s - значение по умолчанию
+	KeepAliveInterval time.Duration `yaml:"KeepAliveInterval" json:"keep_alive_interval"`
+
+	// KeepAliveCount Количество неотвеченных keepalive проб до разрыва соединения (TCP_KEEPCNT). Только для Linux.
+	// Default value: 0 - значение по умолчанию
+	KeepAliveCount uint `yaml:"KeepAliveCount" json:"keep_alive_count"`
+
+	// TCPNoDelayDisable Отключение TCP_NODELAY для принятых соединений, включение алгоритма Нейгла.
+	// Среда выполнения Go включает TCP_NODELAY для всех TCP соединений.
+	// Default value: false - TCP_NODELAY включён
+	TCPNoDelayDisable bool `yaml:"TCPNoDelayDisable" json:"tcp_no_delay_disable"`
+
+	// SocketReceiveBuffer Размер буфера приёма сокета в байтах (SO_RCVBUF).
+	// Для TCP наследуется принятыми соединениями.
+	// Default value: 0 - значение операционной системы
+	SocketReceiveBuffer uint `yaml:"SocketReceiveBuffer" json:"socket_receive_buffer"`
+
+	// SocketSendBuffer Размер буфера отправки сокета в байтах (SO_SNDBUF).
+	// Для TCP наследуется принятыми соединениями.
+	// Default value: 0 - значение операционной системы
+	SocketSendBuffer uint `yaml:"SocketSendBuffer" json:"socket_send_buffer"`
+
+	// TCPDeferAccept Соединение передаётся серверу только после получения первых данных от клиента, но не позднее
+	// указанного времени (TCP_DEFER_ACCEPT), точность - секунды. Только для Linux.
+	// Default value: 0s - отключено
+	TCPDeferAccept time.Duration `yaml:"TCPDeferAccept" json:"tcp_defer_accept"`
+
+	// TCPFastOpen Длина очереди запросов TCP Fast Open (TCP_FASTOPEN). Только для Linux.
+	// Default value: 0 - отключено
+	TCPFastOpen uint `yaml:"TCPFastOpen" json:"tcp_fast_open"`
+
+	// TCPUserTimeout Максимальное время, в течение которого отправленные данные могут оставаться
+	// неподтверждёнными, до принудительного закрытия соединения (TCP_USER_TIMEOUT), точность - миллисекунды.
+	// Наследуется принятыми соединениями. Только для Linux.
+	// Default value: 0s - значение операционной системы
+	TCPUserTimeout time.Duration `yaml:"TCPUserTimeout" json:"tcp_user_timeout"`
+
+	// IPv6Only Сокет IPv6 принимает только IPv6 соединения (IPV6_V6ONLY), без IPv4 адресов вида ::ffff:0:0/96.
+	// Используется для режимов tcp и udp, если адрес является IPv6 адресом, например "[::]:80".
+	// Default value: false - сокет принимает IPv4 и IPv6 соединения
+	IPv6Only bool `yaml:"IPv6Only" json:"ipv6_only"`
+
+	// FreeBind Разрешение открытия порта на IP адресе, который ещё не назначен сетевому интерфейсу (IP_FREEBIND).
+	// Только для Linux.
+	// Default value: false
+	FreeBind bool `yaml:"FreeBind" json:"free_bind"`
+
+	// SocketMark Метка пакетов сокета для маршрутизации и фильтрации (SO_MARK). Только для Linux, требуются права
+	// CAP_NET_ADMIN.
+	// Default value: 0 - метка не устанавливается
+	SocketMark uint32 `yaml:"SocketMark" json:"socket_mark"`
+
+	// ListenBacklog Длина очереди ожидающих соединений слушателя (backlog). Только для Linux.
+	// Default value: 0 - значение /proc/sys/net/core/somaxconn
+	ListenBacklog uint `yaml:"ListenBacklog" json:"listen_backlog"`
+
+	// ReusePort Открытие порта с опцией SO_REUSEPORT, позволяющей нескольким сокетам, в том числе сокетам разных
+	// процессов, открывать один и тот же адрес, входящие соединения распределяются ядром между сокетами.
+	// Только для Linux.
+	// Default value: false
+	ReusePort bool `yaml:"ReusePort" json:"reuse_port"`
+
+	// ReusePortShards Количество сокетов, открываемых на одном адресе при включённом ReusePort. Каждый сокет имеет
+	// собственную очередь входящих соединений, соединения всех сокетов принимаются параллельно и передаются в
+	// основную функцию сервера через один слушатель, либо, через NewListenerShards(), каждый сокет возвращается
+	// отдельным слушателем. Для UDP не используется.
+	// Default value: 0 - один сокет
+	ReusePortShards uint `yaml:"ReusePortShards" json:"reuse_port_shards"`
+
+	// ReusePortCPUSteering Распределение входящих соединений между сокетами по номеру процессора, обработавшего
+	// пакет, программой classic BPF (SO_ATTACH_REUSEPORT_CBPF), номер сокета равен номеру процессора по модулю
+	// ReusePortShards. Используется при количестве сокетов более одного. Только для Linux.
+	// Default value: false - распределение выполняется ядром по хешу адресов соединения
+	ReusePortCPUSteering bool `yaml:"ReusePortCPUSteering" json:"reuse_port_cpu_steering"`
+
+	// MultipathTCP Включение Multipath TCP (MPTCP) для режимов tcp, tcp4, tcp6. Если ядро операционной системы не
+	// поддерживает MPTCP, порт открывается в режиме обычного TCP. Клиенты без поддержки MPTCP подключаются по
+	// обычному TCP. Использование MPTCP принятым соединением проверяется функцией IsMultipathTCP().
+	// Default value: false
+	MultipathTCP bool `yaml:"MultipathTCP" json:"multipath_tcp"`
+
+	// TCPInfoOnClose Сбор статистики TCP соединения (TCP_INFO) при закрытии каждого принятого соединения, метрики
+	// доступны через TCPInfoMetrics(), статистика каждого соединения передаётся функции, назначенной через OnTCPInfo().
+	// Текущая статистика соединения доступна через ConnTCPInfo().
+	// Только для Linux.
+	// Default value: false
+	TCPInfoOnClose bool `yaml:"TCPInfoOnClose" json:"tcp_info_on_close"`
+
+	// Transparent Режим прозрачного проксирования (TPROXY), сокет принимает соединения и пакеты, адресованные на
+	// любые IP адреса (IP_TRANSPARENT, IPV6_TRANSPARENT). Для UDP включается получение исходного адреса назначения
+	// каждого пакета (IP_RECVORIGDSTADDR). Исходный адрес назначения соединения определяется функцией
+	// OriginalDestination(), UDP пакета - функцией ReadFromOriginalDestination(). Только для Linux, требуются
+	// права CAP_NET_ADMIN.
+	// Default value: false
+	Transparent bool `yaml:"Transparent" json:"transparent"`
 }
 
 /**
@@ -89,16 +347,33 @@ type Configuration struct {
       Address: !!str "http://localhost/"
 
       ## IP адрес или имя хоста на котором поднимается сервер, можно указывать 0.0.0.0 для всех ip адресов.
+      ## Так же можно указать имя сетевого интерфейса, тогда порт открывается на текущих адресах интерфейса.
       ## Default value: "0.0.0.0".
       #Host: !!str "[2a03:e2c0:a32::2]"
       #Host: !!str "example.hostname.local"
+      #Host: !!str "wg0"
       Host: !!str "0.0.0.0"
 
+      ## Политика разрешения имени хоста, указанного в Host, в IP адрес, возможные значения:
+      ## first  - Используется первый адрес в порядке, возвращённом DNS резолвером;
+      ## all    - Порт открывается на всех адресах, в которые разрешается имя хоста, кроме UDP;
+      ## ipv4   - Используется первый IPv4 адрес;
+      ## ipv6   - Используется первый IPv6 адрес;
+      ## single - Имя хоста должно разрешаться ровно в один адрес, иначе сервер не запускается.
+      ## Default value: "first"
+      HostResolve: !!str "first"
+
+      ## Привязка сокета к сетевому интерфейсу, указанному в Host, опцией SO_BINDTODEVICE.
+      ## Только для Linux, может требовать права CAP_NET_RAW.
+      ## Default value: false
+      BindToDevice: !!bool false
+
       ## Tcp/ip порт занимаемый сервером.
       ## Default value: 0
       Port: !!int 1080
 
       ## Юникс сокет, на котором поднимается сервер, только для unix-like операционных систем Linux, Unix, Mac.
+      ## Имя сокета, начинающееся с "@", открывается в абстрактном пространстве имён Linux, например "@example".
       ## Default value: ""
       Socket: !!str "run/example.sock"
 
@@ -107,6 +382,36 @@ type Configuration struct {
       ## Default value: "0666"
       SocketMode: !!str "0666"
 
+      ## Владелец юникс-сокета, имя пользователя или числовой идентификатор (UID).
+      ## Default value: "" - владелец не изменяется
+      SocketOwner: !!str ""
+
+      ## Группа юникс-сокета, имя группы или числовой идентификатор (GID).
+      ## Default value: "" - группа не изменяется
+      #SocketGroup: !!str "www-data"
+      SocketGroup: !!str ""
+
+      ## Файловые разрешения доступа к директории юникс-сокета, создаваемой при открытии сокета,
+      ## если директория не существует.
+      ## Default value: "0755"
+      SocketDirectoryMode: !!str "0755"
+
+      ## Список идентификаторов пользователей, процессам которых разрешено подключение к юникс-сокету.
+      ## Учётные данные процесса клиента (SO_PEERCRED) проверяются при приёме соединения, соединение принимается, если
+      ## пользователь указан в SocketPeerUID или группа указана в SocketPeerGID, иначе соединение закрывается.
+      ## Если оба списка пустые, проверка не выполняется. Для TCP/IP соединений не используется. Только для Linux, в
+      ## других операционных системах указание списка является ошибкой открытия слушателя.
+      ## Default value: []
+      #SocketPeerUID:
+      #  - 0
+      #  - 1000
+
+      ## Список идентификаторов групп, процессам которых разрешено подключение к юникс-сокету.
+      ## Проверяется только основная группа процесса клиента, дополнительные группы процесса не учитываются.
+      ## Default value: []
+      #SocketPeerGID:
+      #  - 1000
+
       ## Режим открытия сокета, возможные значения: tcp, tcp4, tcp6, unix, unixpacket, socket, systemd.
       ## udp, udp4, udp6 - Сервер поднимается на указанном Host:Port;
       ## tcp, tcp4, tcp6 - Сервер поднимается на указанном Host:Port;
@@ -120,17 +425,115 @@ type Configuration struct {
       ## Default value: "tcp"
       Mode: !!str "tcp"
 
+      ## Список адресов, на которых одновременно поднимается сервер, для TCP/IP, unix сокетов и systemd.
+      ## Если список указан, параметры Host, Port и Socket не используются для открытия адресов, а Mode определяет
+      ## тип TCP/IP соединения (tcp, tcp4, tcp6). Формат адреса:
+      ## host:port            - TCP/IP адрес, например "0.0.0.0:80" или "[::]:80";
+      ## unix:путь или /путь  - Unix сокет, файловые разрешения задаются SocketMode;
+      ## systemd:имя          - Все сокеты с указанным именем, переданные systemd.
+      ## Так же допускается адрес в формате URL, кроме udp:// и tls://, например "tcp6://[::]:80".
+      ## Адрес "0.0.0.0" открывается только для IPv4, адрес "::" только для IPv6 (IPV6_V6ONLY), поэтому
+      ## "0.0.0.0:80" и "[::]:80" могут быть указаны одновременно.
+      ## Все адреса открываются атомарно, при ошибке открытия любого адреса уже открытые адреса закрываются.
+      ## Соединения всех адресов передаются в основную функцию сервера через один слушатель.
+      ## Для UDP не используется.
+      ## Default value: []
+      #Listen:
+      #  - "0.0.0.0:1080"
+      #  - "[::]:1080"
+      #  - "unix:run/example.sock"
+      Listen: []
+
       ## Путь и имя файла содержащего публичный ключ (сертификат) в PEM формате, включая CA
       ## сертификаты всех промежуточных центров сертификации, если ими подписан ключ.
+      ## Вместо пути к файлу можно указать:
+      ## PEM содержимое - Значение содержащее "-----BEGIN ", используется как есть;
+      ## env:ИМЯ        - Содержимое загружается из переменной окружения;
+      ## credential:ИМЯ - Содержимое загружается из файла учётных данных systemd, в директории
+      ##                  $CREDENTIALS_DIRECTORY (LoadCredential=, LoadCredentialEncrypted=, SetCredential=).
       ## Применяется только для TCP соединений, для UDP не используется.
       ## Default value: ""
       TLSPublicKeyPEM: !!str "/etc/application/certificate.pub"
 
       ## Путь и имя файла содержащего секретный/приватный ключ в PEM формате.
+      ## Ключ может быть зашифрован (PKCS#8 ENCRYPTED PRIVATE KEY или PEM с заголовком Proc-Type: 4,ENCRYPTED),
+      ## либо может быть указан контейнер PKCS#12 (.p12, .pfx), в этом случае TLSPublicKeyPEM можно не указывать.
+      ## Шифрование Proc-Type: 4,ENCRYPTED устарело и небезопасно, поддерживается только для совместимости, для новых
+      ## ключей используйте PKCS#8.
+      ## Вместо пути к файлу можно указать PEM содержимое, env:ИМЯ или credential:ИМЯ, так же как для
+      ## TLSPublicKeyPEM.
       ## Применяется только для TCP соединений, для UDP не используется.
       ## Default value: ""
       TLSPrivateKeyPEM: !!str "/etc/application/certificate.key"
 
+      ## Источник пароля зашифрованного секретного ключа или контейнера PKCS#12.
+      ## Возможные значения:
+      ## file:путь или путь - Пароль загружается из файла;
+      ## env:ИМЯ            - Пароль загружается из переменной окружения;
+      ## credential:ИМЯ     - Пароль загружается из файла учётных данных systemd, в директории $CREDENTIALS_DIRECTORY
+      ##                      (LoadCredential=, LoadCredentialEncrypted=, SetCredential=).
+      ## Завершающие символы перевода строки в пароле удаляются.
+      ## Default value: ""
+      TLSPrivateKeyPassword: !!str "credential:certificate.password"
+
+      ## Режим разработки, создание самоподписанного сертификата.
+      ## Если сервер запускается в режиме TLS, а TLSPublicKeyPEM и TLSPrivateKeyPEM не указаны, в памяти создаётся
+      ## центр сертификации и подписанный им сертификат (ECDSA) для Host и localhost.
+      ## Не используйте в промышленной эксплуатации.
+      ## Default value: false
+      DevSelfSigned: !!bool false
+
+      ## Путь и имя файла, в который сохраняется публичный ключ (сертификат) созданного центра
+      ## сертификации в PEM формате, для добавления в список доверенных сертификатов клиента. Секретный ключ центра
+      ## сертификации сохраняется рядом, в файл с окончанием ".key", и центр сертификации используется повторно при
+      ## следующих запусках сервера, пока не истекает срок его действия.
+      ## Используется только при включённом DevSelfSigned.
+      ## Default value: "" - не сохраняется
+      DevSelfSignedCAFile: !!str "/tmp/application-dev-ca.pem"
+
+      ## Путь и имя файла содержащего ключи сессионных билетов TLS (session ticket keys).
+      ## Ключи указываются по одному в строке, в кодировке base64 или hex, длина каждого ключа 32 байта, либо
+      ## файл содержит ключи в двоичном виде, последовательно по 32 байта. Первый ключ используется для шифрования
+      ## новых билетов, остальные только для расшифровки ранее выданных билетов.
+      ## Один и тот же файл может использоваться несколькими процессами за одним балансировщиком нагрузки, что
+      ## обеспечивает возобновление TLS сессий на любом из процессов. Вместо пути к файлу можно указать env:ИМЯ
+      ## или credential:ИМЯ, так же как для TLSPublicKeyPEM.
+      ## Если не указан, используются случайные ключи, создаваемые каждым процессом.
+      ## Default value: ""
+      TLSSessionTicketKeyFile: !!str "/run/application/ticket.keys"
+
+      ## Интервал ротации ключей сессионных билетов TLS.
+      ## Если указан TLSSessionTicketKeyFile, с этим интервалом ключи повторно загружаются из файла, ротацию ключей
+      ## в файле выполняет внешний процесс. Если файл не указан, процесс сам создаёт новый ключ с этим интервалом,
+      ## сохраняя два предыдущих ключа для расшифровки ранее выданных билетов.
+      ## Default value: 0s - ротация не выполняется
+      TLSSessionTicketKeyRotation: 1h
+
+      ## Путь и имя файла, в который записываются секреты TLS сессий в формате NSS key log, для
+      ## расшифровки перехваченного трафика при отладке, например, с помощью Wireshark.
+      ## ВНИМАНИЕ!!! Позволяет расшифровать весь TLS трафик сервера, при открытии файла предупреждение выводится в
+      ## стандартный поток ошибок процесса и записывается в файл строкой комментария.
+      ## Не используйте в промышленной эксплуатации.
+      ## Default value: "" - выключено
+      TLSKeyLogFile: !!str ""
+
+      ## Выполнение TLS рукопожатия до передачи соединения в основную функцию сервера.
+      ## Соединения с ошибкой рукопожатия закрываются и не передаются в основную функцию сервера, ошибки
+      ## учитываются в счётчиках по причинам, доступных через TLSHandshakeFailures(). Для переданных соединений
+      ## согласованные ALPN и SNI доступны сразу, через ConnectionState().
+      ## Default value: false - рукопожатие выполняется при первом чтении или записи в соединение
+      TLSHandshakeEager: !!bool false
+
+      ## Максимальное время ожидания завершения TLS рукопожатия.
+      ## Время ожидания используется при включённом TLSHandshakeEager и в StartTLS.
+      ## Default value: 0s - используется 10s
+      TLSHandshakeTimeout: 10s
+
+      ## Максимальное количество одновременно выполняемых TLS рукопожатий при включённом TLSHandshakeEager.
+      ## При достижении ограничения приём новых соединений приостанавливается до завершения выполняемых рукопожатий.
+      ## Default value: 0 - используется 1024
+      TLSHandshakeConcurrency: !!int 1024
+
       ## Включение прокси-протокола.
       ## Прокси-протокол позволяет серверу получать информацию о подключении клиента, передаваемую через
       ## прокси-серверы и средства балансировки нагрузки, такие как Nginx, HAProxy, Amazon Elastic Load
@@ -145,5 +548,95 @@ type Configuration struct {
       ## Default value: 0s - no timeout
       ProxyProtocolReadHeaderTimeout: 0s
 
+      ## Отключение TCP keepalive для принятых соединений.
+      ## Default value: false
+      KeepAliveDisable: !!bool false
+
+      ## Время простоя соединения до отправки первой keepalive пробы (TCP_KEEPIDLE).
+      ## Параметры keepalive устанавливаются на сокет слушателя и наследуются принятыми соединениями.
+      ## Только для Linux.
+      ## Default value: 0s - значение по умолчанию
+      KeepAliveIdle: 0s
+
+      ## Интервал между keepalive пробами (TCP_KEEPINTVL). Только для Linux.
+      ## Default value: 0s - значение по умолчанию
+      KeepAliveInterval: 0s
+
+      ## Количество неотвеченных keepalive проб до разрыва соединения (TCP_KEEPCNT). Только для Linux.
+      ## Default value: 0 - значение по умолчанию
+      KeepAliveCount: !!int 0
+
+      ## Отключение TCP_NODELAY для принятых соединений, включение алгоритма Нейгла.
+      ## Default value: false - TCP_NODELAY включён
+      TCPNoDelayDisable: !!bool false
+
+      ## Размер буфера приёма сокета в байтах (SO_RCVBUF).
+      ## Default value: 0 - значение операционной системы
+      SocketReceiveBuffer: !!int 0
+
+      ## Размер буфера отправки сокета в байтах (SO_SNDBUF).
+      ## Default value: 0 - значение операционной системы
+      SocketSendBuffer: !!int 0
+
+      ## Соединение передаётся серверу только после получения первых данных от клиента, но не позднее
+      ## указанного времени (TCP_DEFER_ACCEPT). Только для Linux.
+      ## Default value: 0s - отключено
+      TCPDeferAccept: 0s
+
+      ## Длина очереди запросов TCP Fast Open (TCP_FASTOPEN). Только для Linux.
+      ## Default value: 0 - отключено
+      TCPFastOpen: !!int 0
+
+      ## Максимальное время, в течение которого отправленные данные могут оставаться неподтверждёнными
+      ## (TCP_USER_TIMEOUT). Только для Linux.
+      ## Default value: 0s - значение операционной системы
+      TCPUserTimeout: 0s
+
+      ## Сокет IPv6 принимает только IPv6 соединения (IPV6_V6ONLY).
+      ## Default value: false
+      IPv6Only: !!bool false
+
+      ## Разрешение открытия порта на IP адресе, который ещё не назначен сетевому интерфейсу (IP_FREEBIND).
+      ## Только для Linux.
+      ## Default value: false
+      FreeBind: !!bool false
+
+      ## Метка пакетов сокета для маршрутизации и фильтрации (SO_MARK). Только для Linux.
+      ## Default value: 0 - метка не устанавливается
+      SocketMark: !!int 0
+
+      ## Длина очереди ожидающих соединений слушателя (backlog). Только для Linux.
+      ## Default value: 0 - значение /proc/sys/net/core/somaxconn
+      ListenBacklog: !!int 0
+
+      ## Открытие порта с опцией SO_REUSEPORT. Только для Linux.
+      ## Default value: false
+      ReusePort: !!bool false
+
+      ## Количество сокетов, открываемых на одном адресе при включённом ReusePort, соединения всех сокетов
+      ## принимаются параллельно.
+      ## Default value: 0 - один сокет
+      ReusePortShards: !!int 0
+
+      ## Распределение входящих соединений между сокетами по номеру процессора (SO_ATTACH_REUSEPORT_CBPF).
+      ## Только для Linux.
+      ## Default value: false
+      ReusePortCPUSteering: !!bool false
+
+      ## Включение Multipath TCP (MPTCP) для режимов tcp, tcp4, tcp6. Если ядро операционной системы не
+      ## поддерживает MPTCP, порт открывается в режиме обычного TCP.
+      ## Default value: false
+      MultipathTCP: !!bool false
+
+      ## Сбор статистики TCP соединения (TCP_INFO) при закрытии каждого принятого соединения.
+      ## Только для Linux.
+      ## Default value: false
+      TCPInfoOnClose: !!bool false
+
+      ## Режим прозрачного проксирования (TPROXY), сокет принимает соединения и пакеты, адресованные на
+      ## любые IP адреса (IP_TRANSPARENT). Только для Linux, требуются права CAP_NET_ADMIN.
+      ## Default value: false
+      Transparent: !!bool false
+
 
 **/
@@ -1,8 +1,14 @@
 package net
 
-import "fmt"
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
 
 // HostPort Формирование строки HOST:PORT.
+// IPv6 адрес, в том числе с указанием зоны, заключается в квадратные скобки: [fe80::1%eth0]:80.
 func (uco *Configuration) HostPort() (ret string) {
 	switch uco.Mode {
 	case netUnix, netUnixPacket:
@@ -10,7 +16,10 @@ func (uco *Configuration) HostPort() (ret string) {
 	case netSystemd:
 		ret = uco.Mode
 	default:
-		ret = fmt.Sprintf("%s:%d", uco.Host, uco.Port)
+		ret = net.JoinHostPort(
+			strings.TrimSuffix(strings.TrimPrefix(uco.Host, "["), "]"),
+			strconv.FormatUint(uint64(uco.Port), 10),
+		)
 	}
 
 	return
@@ -23,6 +23,8 @@ type Interface interface {
 
 	// ListenAndServe Открытие адреса или сокета без использования конфигурации сервера (конфигурация по
 	// умолчанию), после успешного открытия адреса, выполняется запуск сервера для обслуживания входящих соединений.
+	// Адрес может быть указан в формате URL: tcp4://, tcp6://, udp://host:port, unix:///путь?mode=0660, unix:@имя,
+	// systemd://имя, tls://host:port?cert=...&key=...
 	ListenAndServe(addr string) Interface
 
 	// ListenAndServeTLS Открытие адреса или сокета с использованием TLS, без использования конфигурации сервера
@@ -63,9 +65,47 @@ type Interface interface {
 	// сервера.
 	NewListenerTLS(conf *Configuration, tlsConfig *tls.Config) (ret net.Listener, rpc net.PacketConn, err error)
 
+	// NewListenerShards Создание отдельных слушателей для всех сокетов ReusePortShards, открытых на одном TCP/IP
+	// адресе с опцией SO_REUSEPORT. Каждый слушатель имеет собственную очередь входящих соединений и может
+	// обслуживаться отдельной горутиной. К слушателям применяются те же обёртки, что и в NewListener:
+	// TCPInfoOnClose, SocketPeerUID, SocketPeerGID и ProxyProtocol. TLS не применяется, для TLS каждый слушатель
+	// оборачивается через tls.NewListener с общей TLS конфигурацией, чтобы сессии возобновлялись на любом слушателе.
+	NewListenerShards(conf *Configuration) (ret []net.Listener, err error)
+
 	// NewTLSConfigDefault Создание TLS конфигурации по умолчанию, на основе секретного и публичного ключей.
+	// Вместо пути к файлу можно указать PEM содержимое, ссылку на переменную окружения "env:ИМЯ" или ссылку на
+	// учётные данные systemd "credential:ИМЯ".
 	NewTLSConfigDefault(tlsPublicFile string, tlsPrivateFile string) (ret *tls.Config, err error)
 
+	// NewTLSConfig Создание TLS конфигурации по умолчанию на основе конфигурации сервера.
+	// Секретный ключ может быть зашифрован или находиться в контейнере PKCS#12, пароль к ключу загружается из
+	// источника указанного в TLSPrivateKeyPassword.
+	// Если ключи не указаны и включён DevSelfSigned, создаётся самоподписанный сертификат для режима разработки.
+	NewTLSConfig(conf *Configuration) (ret *tls.Config, err error)
+
+	// TLSHandshakeFailures Счётчики ошибок TLS рукопожатия по причинам, для слушателей созданных с включённым
+	// TLSHandshakeEager. Причины: timeout - истекло время ожидания рукопожатия, closed - клиент закрыл
+	// соединение, not_tls - клиент прислал данные не являющиеся TLS, remote_alert - клиент прервал рукопожатие
+	// сообщением об ошибке, tls - ошибка протокола TLS, other - прочие ошибки.
+	TLSHandshakeFailures() map[string]uint64
+
+	// StartTLS Переключение соединения, начатого без шифрования, в режим TLS (STARTTLS), например, для протоколов
+	// SMTP, IMAP, LDAP. Рукопожатие выполняется сразу, с ограничением времени TLSHandshakeTimeout, ошибки
+	// рукопожатия учитываются в TLSHandshakeFailures(), соединение с ошибкой рукопожатия закрывается.
+	// Если tlsConfig не указан, используется TLS конфигурация слушателя сервера, запущенного в режиме TLS, или TLS
+	// конфигурация, созданная на основе конфигурации сервера так же, как в NewListenerTLS. Созданная конфигурация и
+	// соединения с выполняемым рукопожатием освобождаются при остановке сервера.
+	StartTLS(conn net.Conn, tlsConfig *tls.Config) (ret *tls.Conn, err error)
+
+	// TCPInfoMetrics Метрики статистики TCP соединений (TCP_INFO), собранные при закрытии соединений слушателей,
+	// созданных с включённым TCPInfoOnClose: время приёма-передачи, повторные передачи, потери.
+	TCPInfoMetrics() TCPInfoMetrics
+
+	// OnTCPInfo Назначение функции, получающей адрес клиента и статистику TCP соединения (TCP_INFO) при закрытии
+	// каждого соединения слушателей, созданных с включённым TCPInfoOnClose. Функция должна назначаться до запуска
+	// сервера и не должна блокировать выполнение, так как вызывается при закрытии соединения.
+	OnTCPInfo(fn TCPInfoFn) Interface
+
 	// СЕРВЕР
 
 	// Serve Запуск функции сервера для входящих соединений на основе переданного слушателя net.Listener.
@@ -82,6 +122,22 @@ type Interface interface {
 	// указанием ID сервера.
 	ServeUdpWithId(lpc net.PacketConn, id string) Interface
 
+	// Addr Адрес, на котором сервер фактически принимает соединения. Если порт в конфигурации не указан или равен
+	// 0, возвращается порт, назначенный операционной системой. Для сокетов systemd возвращается адрес переданного
+	// сокета. Если сервер слушает несколько адресов, возвращается первый из них. Если сервер не запущен,
+	// возвращается nil.
+	Addr() net.Addr
+
+	// BoundAddresses Все адреса, на которых сервер фактически принимает соединения, в том числе адреса из списка
+	// Listen конфигурации сервера. Если сервер не запущен, возвращается nil.
+	BoundAddresses() []net.Addr
+
+	// BoundConfiguration Копия конфигурации сервера, в которой адрес заменён на адрес, фактически назначенный
+	// операционной системой или полученный от systemd. Host и Port заполняются из первого адреса, Listen
+	// заменяется списком всех адресов. Исходная конфигурация сервера не изменяется. Если сервер не запущен,
+	// возвращается nil.
+	BoundConfiguration() *Configuration
+
 	// Wait Блокируемая функция ожидания завершения веб сервера, если он запущен.
 	// Если сервер не запущен, функция завершается немедленно.
 	Wait() Interface
@@ -0,0 +1,84 @@
+package net
+
+import (
+	"net"
+	"sync"
+	"time"
+)
+
+// Результаты проверки начальных байтов соединения функцией MuxMatcher.
+const (
+	MuxMismatch MuxResult = iota // Начальные байты не соответствуют протоколу.
+	MuxMatch                     // Начальные байты соответствуют протоколу.
+	MuxNeedMore                  // Для принятия решения необходимо больше данных.
+)
+
+// Значения по умолчанию для разделителя соединений.
+const (
+	muxPeekSizeInitial    = 512              // Начальный размер буфера для начальных байтов соединения.
+	muxDefaultPeekSize    = 5 + 16384        // Максимальный размер записи TLS, достаточный для TLS ClientHello.
+	muxDefaultPeekTimeout = time.Second * 10 // Время ожидания начальных байтов соединения.
+)
+
+// MuxResult Результат проверки начальных байтов соединения.
+type MuxResult int
+
+// MuxMatcher Функция проверки начальных байтов соединения.
+// В функцию передаются все прочитанные из соединения байты, функция вызывается повторно по мере поступления
+// данных, пока не вернёт MuxMatch или MuxMismatch.
+type MuxMatcher func(head []byte) MuxResult
+
+// Mux Интерфейс разделителя соединений по протоколам на одном слушателе.
+// Разделитель читает начальные байты каждого соединения и передаёт соединение в слушатель, функция проверки
+// которого первой подтвердила соответствие протоколу. Прочитанные байты повторно отдаются обработчику
+// соединения при чтении. Каждый слушатель можно передать в Serve отдельного сервера Interface.
+type Mux interface {
+	// Match Создание слушателя для соединений, начальные байты которых соответствуют одной из функций проверки.
+	// Слушатели проверяются в порядке создания.
+	Match(matchers ...MuxMatcher) net.Listener
+
+	// Serve Запуск приёма и разделения соединений. Функция блокируется до закрытия слушателя.
+	// Ошибки нехватки ресурсов при приёме соединения не завершают функцию, приём соединений повторяется с задержкой.
+	Serve() error
+
+	// Close Закрытие исходного слушателя и всех созданных слушателей.
+	Close() error
+}
+
+// Объект сущности, реализующий интерфейс Mux.
+type mux struct {
+	root        net.Listener  // Исходный слушатель соединений.
+	peekSize    int           // Максимальное количество байтов, читаемых для определения протокола.
+	peekTimeout time.Duration // Максимальное время ожидания начальных байтов соединения.
+	lck         *sync.RWMutex // Защита от гонки.
+	routes      []*muxRoute   // Слушатели с функциями проверки.
+	done        chan struct{} // Канал закрытия разделителя.
+	once        *sync.Once    // Однократное закрытие разделителя.
+}
+
+// Слушатель с функциями проверки.
+type muxRoute struct {
+	matchers []MuxMatcher
+	listener *muxListener
+}
+
+// Слушатель соединений, выделенных разделителем.
+type muxListener struct {
+	addr  net.Addr      // Адрес исходного слушателя.
+	conns chan net.Conn // Соединения, соответствующие протоколу.
+	done  chan struct{} // Канал закрытия слушателя.
+	once  *sync.Once    // Однократное закрытие слушателя.
+}
+
+// Соединение, повторно отдающее прочитанные разделителем начальные байты.
+type muxConn struct {
+	net.Conn
+	peek []byte // Все прочитанные разделителем начальные байты.
+	head []byte // Ещё не отданные при чтении начальные байты.
+}
+
+// MuxTLSClientHello Данные TLS ClientHello, прочитанные разделителем без завершения TLS.
+type MuxTLSClientHello struct {
+	ServerName string   // Имя сервера (SNI), пустая строка, если клиент не передал имя сервера.
+	Protocols  []string // Протоколы прикладного уровня (ALPN) в порядке предпочтения клиента.
+}
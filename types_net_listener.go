@@ -1,6 +1,10 @@
 package net
 
-import "net"
+import (
+	"net"
+	"os"
+	"sync"
+)
 
 // Внутренняя структура хранения интерфейса слушателя соединения.
 type netListener struct {
@@ -46,3 +50,21 @@ func netListenerUdp(l net.PacketConn) (ret *netListener) {
 func netListenerTcp(l net.Listener) (ret *netListener) {
 	return &netListener{tcp: l}
 }
+
+// Слушатель юникс-сокета, удаляющий при закрытии только собственный файл сокета.
+type unixSocketListener struct {
+	*net.UnixListener
+	path string      // Путь к файлу сокета.
+	file os.FileInfo // Файл сокета, созданный слушателем.
+	addr net.Addr    // Адрес слушателя.
+	once sync.Once   // Однократное удаление файла сокета.
+}
+
+// Слушатель, объединяющий соединения нескольких слушателей.
+type multiListener struct {
+	listeners []net.Listener // Объединяемые слушатели.
+	conns     chan net.Conn  // Принятые соединения всех слушателей.
+	errs      chan error     // Ошибки приёма соединений.
+	done      chan struct{}  // Канал закрытия слушателя.
+	once      *sync.Once     // Однократное закрытие слушателя.
+}
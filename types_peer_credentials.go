@@ -0,0 +1,19 @@
+package net
+
+import "net"
+
+// PeerCredentials Учётные данные процесса клиента юникс-сокета, полученные из ядра операционной системы в момент
+// подключения клиента (SO_PEERCRED, SO_PEERSEC).
+type PeerCredentials struct {
+	PID           int32  // Идентификатор процесса клиента.
+	UID           uint32 // Идентификатор пользователя процесса клиента.
+	GID           uint32 // Идентификатор группы процесса клиента.
+	SecurityLabel string // Метка безопасности процесса клиента (SELinux, AppArmor, Smack), если доступна.
+}
+
+// Слушатель соединений, принимающий соединения юникс-сокета только от разрешённых пользователей и групп.
+type peerListener struct {
+	net.Listener
+	uid map[uint32]bool // Разрешённые идентификаторы пользователей.
+	gid map[uint32]bool // Разрешённые идентификаторы групп.
+}
@@ -0,0 +1,70 @@
+package net
+
+import (
+	"sync"
+	"time"
+)
+
+// Алгоритмы балансировки нагрузки проксирования.
+const (
+	ProxyBalanceRoundRobin = "round-robin" // Циклический перебор вышестоящих серверов.
+	ProxyBalanceLeastConn  = "least-conn"  // Выбор вышестоящего сервера с наименьшим количеством соединений.
+)
+
+// Значения по умолчанию для конфигурации проксирования.
+const (
+	proxyDefaultConnectTimeout = time.Second * 10
+	proxyDefaultMaxFails       = 1
+	proxyDefaultFailTimeout    = time.Second * 10
+)
+
+// ProxyConfiguration Конфигурация основной функции TCP сервера, проксирующей соединения на вышестоящие серверы.
+type ProxyConfiguration struct {
+	// Upstream Адреса вышестоящих серверов.
+	// Адрес указывается так же, как адрес сервера: "host:port", "tcp://host:port", "tcp4://host:port" или
+	// "tcp6://host:port" для TCP/IP, "unix:/путь/к/сокету", "unix:///путь/к/сокету" или "/путь/к/сокету" для
+	// unix сокета.
+	// Default value: []
+	Upstream []string `yaml:"Upstream" json:"upstream"`
+
+	// Balance Алгоритм балансировки нагрузки между вышестоящими серверами, возможные значения:
+	// round-robin - Циклический перебор вышестоящих серверов;
+	// least-conn  - Выбор вышестоящего сервера с наименьшим количеством активных соединений.
+	// Default value: "round-robin"
+	Balance string `yaml:"Balance" json:"balance" default-value:"round-robin"`
+
+	// ConnectTimeout Максимальное время ожидания соединения с вышестоящим сервером.
+	// Default value: 10s
+	ConnectTimeout time.Duration `yaml:"ConnectTimeout" json:"connect_timeout" default-value:"10s"`
+
+	// MaxFails Количество подряд неудачных попыток соединения, после которого вышестоящий сервер считается
+	// недоступным на время FailTimeout.
+	// Default value: 1
+	MaxFails uint `yaml:"MaxFails" json:"max_fails" default-value:"1"`
+
+	// FailTimeout Время, в течение которого недоступный вышестоящий сервер исключается из балансировки.
+	// Default value: 10s
+	FailTimeout time.Duration `yaml:"FailTimeout" json:"fail_timeout" default-value:"10s"`
+
+	// ProxyProtocol Версия заголовка прокси-протокола, передаваемого вышестоящему серверу перед данными
+	// соединения, возможные значения: 0 - заголовок не передаётся, 1 - текстовый заголовок, 2 - двоичный заголовок.
+	// Default value: 0
+	ProxyProtocol uint8 `yaml:"ProxyProtocol" json:"proxy_protocol"`
+}
+
+// Объект сущности основной функции TCP сервера, проксирующей соединения.
+type proxy struct {
+	conf      *ProxyConfiguration // Конфигурация проксирования.
+	upstreams []*proxyUpstream    // Вышестоящие серверы.
+	lck       *sync.Mutex         // Защита от гонки.
+	next      int                 // Индекс следующего вышестоящего сервера для циклического перебора.
+}
+
+// Вышестоящий сервер проксирования.
+type proxyUpstream struct {
+	network string    // Тип соединения.
+	address string    // Адрес соединения.
+	active  int       // Количество активных соединений.
+	fails   uint      // Количество подряд неудачных попыток соединения.
+	downTo  time.Time // Время, до которого вышестоящий сервер исключён из балансировки.
+}
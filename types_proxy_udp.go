@@ -0,0 +1,89 @@
+package net
+
+import (
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Значения по умолчанию для конфигурации проксирования UDP.
+const (
+	udpProxyDefaultIdleTimeout = time.Minute
+	udpProxyDefaultBufferSize  = 65535
+)
+
+// UdpProxyConfiguration Конфигурация основной функции UDP сервера, проксирующей пакеты на вышестоящие серверы.
+type UdpProxyConfiguration struct {
+	// Upstream Адреса вышестоящих UDP серверов в формате "host:port".
+	// Для каждого нового клиента вышестоящий сервер выбирается циклическим перебором.
+	// Имена узлов разрешаются один раз при создании проксирования.
+	// Default value: []
+	Upstream []string `yaml:"Upstream" json:"upstream"`
+
+	// IdleTimeout Время, после которого сессия клиента без входящих и исходящих пакетов завершается.
+	// Default value: 1m
+	IdleTimeout time.Duration `yaml:"IdleTimeout" json:"idle_timeout" default-value:"1m"`
+
+	// MaxSessions Максимальное количество одновременных сессий, пакеты новых клиентов сверх лимита отбрасываются.
+	// Default value: 0 - no limit
+	MaxSessions uint `yaml:"MaxSessions" json:"max_sessions"`
+
+	// BufferSize Размер буфера чтения пакета.
+	// Default value: 65535
+	BufferSize uint `yaml:"BufferSize" json:"buffer_size" default-value:"65535"`
+}
+
+// UdpProxy Интерфейс проксирования UDP пакетов с отслеживанием сессий клиентов.
+// Сессия клиента определяется адресом клиента, для каждой сессии открывается отдельный сокет к вышестоящему
+// серверу, ответы вышестоящего сервера передаются клиенту через слушателя сервера.
+type UdpProxy interface {
+	// Handler Основная функция UDP сервера.
+	Handler() HandlerUdpFn
+
+	// Metrics Счётчики проксирования.
+	Metrics() UdpProxyMetrics
+}
+
+// UdpProxyMetrics Счётчики проксирования UDP пакетов.
+type UdpProxyMetrics struct {
+	Sessions         uint64 // Количество активных сессий.
+	SessionsTotal    uint64 // Количество созданных сессий.
+	SessionsExpired  uint64 // Количество сессий, завершённых по времени бездействия.
+	SessionsRejected uint64 // Количество пакетов новых клиентов, отброшенных из-за лимита сессий.
+	PacketsIn        uint64 // Количество пакетов от клиентов к вышестоящим серверам.
+	PacketsOut       uint64 // Количество пакетов от вышестоящих серверов к клиентам.
+	BytesIn          uint64 // Количество байтов от клиентов к вышестоящим серверам.
+	BytesOut         uint64 // Количество байтов от вышестоящих серверов к клиентам.
+	Errors           uint64 // Количество ошибок открытия сокетов, чтения и отправки пакетов.
+}
+
+// Объект сущности, реализующий интерфейс UdpProxy.
+type udpProxy struct {
+	conf      *UdpProxyConfiguration // Конфигурация проксирования.
+	upstreams []*net.UDPAddr         // Адреса вышестоящих серверов, разрешённые при создании проксирования.
+	next      *atomic.Uint64         // Счётчик циклического перебора вышестоящих серверов.
+	lck       *sync.Mutex            // Защита от гонки.
+	sessions  map[string]*udpSession // Активные сессии по адресу клиента.
+	metrics   *udpProxyMetrics       // Счётчики проксирования.
+}
+
+// Сессия клиента проксирования UDP.
+type udpSession struct {
+	client   net.Addr      // Адрес клиента.
+	upstream *net.UDPConn  // Сокет к вышестоящему серверу.
+	last     *atomic.Int64 // Время последнего пакета сессии в наносекундах.
+}
+
+// Счётчики проксирования UDP пакетов.
+type udpProxyMetrics struct {
+	sessions         atomic.Int64
+	sessionsTotal    atomic.Uint64
+	sessionsExpired  atomic.Uint64
+	sessionsRejected atomic.Uint64
+	packetsIn        atomic.Uint64
+	packetsOut       atomic.Uint64
+	bytesIn          atomic.Uint64
+	bytesOut         atomic.Uint64
+	errors           atomic.Uint64
+}
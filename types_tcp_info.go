@@ -0,0 +1,63 @@
+package net
+
+import (
+	"net"
+	"sync"
+	"time"
+)
+
+// TCPInfo Статистика TCP соединения, полученная из ядра операционной системы (TCP_INFO).
+type TCPInfo struct {
+	RTT           time.Duration // Сглаженное время приёма-передачи (RTT).
+	RTTVar        time.Duration // Отклонение времени приёма-передачи.
+	MinRTT        time.Duration // Минимальное время приёма-передачи за время жизни соединения.
+	Retransmits   uint8         // Количество повторных передач текущего неподтверждённого сегмента.
+	TotalRetrans  uint32        // Количество повторно переданных сегментов за время жизни соединения.
+	Lost          uint32        // Количество сегментов, считающихся потерянными.
+	SndCwnd       uint32        // Размер окна перегрузки в сегментах.
+	SndMSS        uint32        // Максимальный размер отправляемого сегмента в байтах.
+	PMTU          uint32        // Максимальный размер пакета на пути к клиенту (Path MTU) в байтах.
+	DeliveryRate  uint64        // Оценка скорости доставки данных клиенту в байтах в секунду.
+	BytesSent     uint64        // Количество отправленных байтов, включая повторные передачи.
+	BytesRetrans  uint64        // Количество повторно переданных байтов.
+	BytesReceived uint64        // Количество полученных байтов.
+}
+
+// TCPInfoFn Описание типа функции, получающей статистику TCP соединения при его закрытии.
+type TCPInfoFn func(remote net.Addr, info *TCPInfo)
+
+// TCPInfoMetrics Метрики статистики TCP соединений, собранные при закрытии соединений.
+type TCPInfoMetrics struct {
+	Samples      uint64        // Количество закрытых соединений, для которых получена статистика.
+	Errors       uint64        // Количество закрытых соединений, для которых статистику получить не удалось.
+	RTTAvg       time.Duration // Среднее время приёма-передачи.
+	RTTMax       time.Duration // Максимальное время приёма-передачи.
+	RTTVarAvg    time.Duration // Среднее отклонение времени приёма-передачи.
+	TotalRetrans uint64        // Количество повторно переданных сегментов всех соединений.
+	Lost         uint64        // Количество потерянных сегментов всех соединений.
+	BytesSent    uint64        // Количество отправленных байтов всех соединений.
+	BytesRetrans uint64        // Количество повторно переданных байтов всех соединений.
+}
+
+// Накопление метрик статистики TCP соединений.
+type tcpInfoMetrics struct {
+	lck     *sync.Mutex
+	metrics TCPInfoMetrics
+	rttSum  time.Duration // Сумма времени приёма-передачи для расчёта среднего значения.
+	varSum  time.Duration // Сумма отклонений времени приёма-передачи для расчёта среднего значения.
+	fn      TCPInfoFn     // Функция, получающая статистику каждого закрываемого соединения.
+}
+
+// Слушатель соединений, собирающий статистику TCP соединений при их закрытии.
+type tcpInfoListener struct {
+	net.Listener
+	metrics *tcpInfoMetrics // Метрики статистики TCP соединений.
+}
+
+// Соединение, собирающее статистику TCP соединения при закрытии.
+type tcpInfoConn struct {
+	net.Conn
+	tcp     *net.TCPConn    // Исходное TCP соединение.
+	metrics *tcpInfoMetrics // Метрики статистики TCP соединений.
+	once    *sync.Once      // Однократный сбор статистики.
+}
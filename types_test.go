@@ -32,6 +32,17 @@ func TestHostPort(t *testing.T) {
 	if hpo = uco.HostPort(); hpo != hpe {
 		t.Errorf("функция HostPort, результат: %q, ожидалось: %q", hpo, hpe)
 	}
+	// IPv6
+	for _, host := range []string{"::1", "[::1]"} {
+		uco.Host = host
+		if hpo = uco.HostPort(); hpo != fmt.Sprintf("[::1]:%d", vPort) {
+			t.Errorf("функция HostPort, результат: %q, ожидалось: %q", hpo, fmt.Sprintf("[::1]:%d", vPort))
+		}
+	}
+	uco.Host = "fe80::1%eth0"
+	if hpo = uco.HostPort(); hpo != fmt.Sprintf("[fe80::1%%eth0]:%d", vPort) {
+		t.Errorf("функция HostPort, результат: %q, ожидалось: %q", hpo, fmt.Sprintf("[fe80::1%%eth0]:%d", vPort))
+	}
 	// Socket
 	uco.Mode = vModeSocket
 	hpe = fmt.Sprintf("%s:%s", netUnix, vSocket)
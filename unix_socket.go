@@ -0,0 +1,361 @@
+package net
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	defaultSocketDirectoryMode = 0755    // Файловые разрешения доступа к создаваемой директории юникс-сокета.
+	socketLockSuffix           = ".lock" // Окончание имени файла блокировки юникс-сокета.
+)
+
+// Открытие юникс-сокета: создание директории сокета, удаление оставшегося от завершённого процесса сокета,
+// открытие сокета с установкой файловых разрешений доступа, владельца и группы сокета. При ошибке установки прав
+// доступа сокет закрывается и не используется.
+// Сокет в абстрактном пространстве имён Linux не имеет файла, для него выполняется только открытие сокета.
+func listenUnix(conf *Configuration) (ret net.Listener, err error) {
+	var (
+		uid, gid int
+		mode     os.FileMode
+		lock     *os.File
+		created  bool
+	)
+
+	if isAbstractSocket(conf.Socket) {
+		if !abstractSocketSupported {
+			err = fmt.Errorf("%w %q", Errors().AbstractSocketNotSupported(), conf.Socket)
+			return
+		}
+		ret, err = net.Listen(conf.Mode, conf.Socket)
+		return
+	}
+	if mode, err = socketMode(conf); err != nil {
+		return
+	}
+	if uid, gid, err = socketOwnership(conf); err != nil {
+		return
+	}
+	if err = socketDirectory(conf, uid, gid); err != nil {
+		return
+	}
+	if lock, created, err = socketLock(conf.Socket); err != nil {
+		return
+	}
+	defer func() { socketUnlock(lock, err == nil || created) }()
+	if err = socketRemoveStale(conf); err != nil {
+		return
+	}
+	ret, err = listenUnixSocket(conf, mode, uid, gid)
+
+	return
+}
+
+// Захват файла блокировки юникс-сокета "путь.lock" на время проверки и открытия сокета, защищающего от
+// одновременного открытия сокета несколькими процессами. Если файл заблокирован другим процессом, возвращается
+// ошибка использования сокета, ошибки создания файла блокировки возвращаются как ошибки доступа.
+// Файл блокировки удаляется процессом, открывшим сокет, поэтому после захвата блокировки проверяется, что путь
+// указывает на заблокированный файл, иначе блокировка получена на удалённом файле и захват повторяется.
+// Возвращается истина, если файл блокировки создан текущим процессом.
+func socketLock(socket string) (ret *os.File, created bool, err error) {
+	const (
+		lockMode     = 0600
+		lockAttempts = 8
+	)
+	var (
+		name       string
+		fdi, pathi os.FileInfo
+		n          int
+	)
+
+	name = socket + socketLockSuffix
+	for n = 0; n < lockAttempts; n++ {
+		if ret, err = os.OpenFile(name, os.O_CREATE|os.O_EXCL|os.O_RDWR, lockMode); err == nil {
+			created = true
+		} else if errors.Is(err, os.ErrExist) {
+			created = false
+			if ret, err = os.OpenFile(name, os.O_RDWR, lockMode); errors.Is(err, os.ErrNotExist) {
+				// Файл блокировки удалён процессом, открывшим сокет, после проверки существования файла.
+				continue
+			}
+		}
+		if err != nil {
+			ret, err = nil, fmt.Errorf("%w %q: %s", Errors().SocketAccess(), name, err)
+			return
+		}
+		switch err = lockFile(ret); {
+		case err == nil:
+		case errors.Is(err, syscall.EWOULDBLOCK):
+			_ = ret.Close()
+			ret, err = nil, fmt.Errorf("%w %q: %s", Errors().SocketInUse(), socket, err)
+			return
+		default:
+			socketUnlock(ret, created)
+			ret, err = nil, fmt.Errorf("%w %q: %s", Errors().SocketAccess(), name, err)
+			return
+		}
+		if fdi, err = ret.Stat(); err != nil {
+			socketUnlock(ret, created)
+			ret, err = nil, fmt.Errorf("%w %q: %s", Errors().SocketAccess(), name, err)
+			return
+		}
+		if pathi, err = os.Stat(name); err == nil && os.SameFile(fdi, pathi) {
+			return
+		}
+		_ = ret.Close()
+	}
+	ret, created, err = nil, false, fmt.Errorf("%w %q", Errors().SocketInUse(), socket)
+
+	return
+}
+
+// Освобождение файла блокировки юникс-сокета. Файл блокировки удаляется до снятия блокировки после успешного
+// открытия сокета, так как процесс, ожидавший блокировку, обнаружит работающий сокет при проверке подключением, а
+// также при ошибке открытия сокета, если файл блокировки создан текущим процессом.
+func socketUnlock(lock *os.File, remove bool) {
+	if remove {
+		_ = os.Remove(lock.Name())
+	}
+	_ = lock.Close()
+}
+
+// Удаление юникс-сокета, оставшегося от завершённого процесса. Удаляется только файл сокета, к которому не удаётся
+// подключиться. Если к сокету есть подключение, возвращается ошибка использования сокета, если путь занят файлом,
+// не являющимся сокетом, возвращается ошибка. Ошибки доступа к пути сокета не считаются использованием сокета.
+func socketRemoveStale(conf *Configuration) (err error) {
+	const probeTimeout = time.Second
+	var (
+		fi   os.FileInfo
+		conn net.Conn
+	)
+
+	if fi, err = os.Lstat(conf.Socket); errors.Is(err, os.ErrNotExist) {
+		err = nil
+		return
+	} else if err != nil {
+		err = fmt.Errorf("%w %q: %s", Errors().SocketAccess(), conf.Socket, err)
+		return
+	}
+	if fi.Mode()&os.ModeSocket == 0 {
+		err = fmt.Errorf("%w %q, %s", Errors().SocketNotSocket(), conf.Socket, fi.Mode())
+		return
+	}
+	switch conn, err = net.DialTimeout(conf.Mode, conf.Socket, probeTimeout); {
+	case err == nil:
+		_ = conn.Close()
+		err = fmt.Errorf("%w %q", Errors().SocketInUse(), conf.Socket)
+	case errors.Is(err, syscall.EPROTOTYPE):
+		// Сокет открыт работающим процессом с другим типом сокета (unix или unixpacket).
+		err = fmt.Errorf("%w %q: %s", Errors().SocketInUse(), conf.Socket, err)
+	case errors.Is(err, syscall.ECONNREFUSED), errors.Is(err, os.ErrNotExist):
+		// Сокет не принимает соединения, процесс, открывший сокет, завершён.
+		if err = os.Remove(conf.Socket); errors.Is(err, os.ErrNotExist) {
+			err = nil
+		} else if err != nil {
+			err = fmt.Errorf("%w %q: %s", Errors().SocketAccess(), conf.Socket, err)
+		}
+	case errors.Is(err, os.ErrDeadlineExceeded):
+		// Очередь входящих соединений работающего процесса заполнена.
+		err = fmt.Errorf("%w %q: %s", Errors().SocketInUse(), conf.Socket, err)
+	default:
+		err = fmt.Errorf("%w %q: %s", Errors().SocketAccess(), conf.Socket, err)
+	}
+
+	return
+}
+
+// Открытие юникс-сокета в файловой системе. Сокет создаётся во временной директории рядом с путём сокета,
+// доступной только владельцу процесса, после установки прав доступа, владельца и группы сокет переносится на путь
+// сокета. Поэтому к сокету невозможно подключиться до установки прав доступа.
+// Если путь сокета во временной директории превышает максимальную длину пути юникс-сокета, сокет открывается сразу
+// на пути сокета, права доступа устанавливаются после открытия сокета.
+// Файл сокета удаляется при закрытии слушателя, только если путь всё ещё указывает на открытый слушателем сокет, а не
+// на сокет, открытый другим процессом после закрытия слушателя.
+func listenUnixSocket(conf *Configuration, mode os.FileMode, uid int, gid int) (ret net.Listener, err error) {
+	const tmpSocketName = "s"
+	var (
+		tmp, name string
+		ltn       net.Listener
+		ul        *net.UnixListener
+		fi        os.FileInfo
+		ok        bool
+	)
+
+	if tmp, err = os.MkdirTemp(filepath.Dir(conf.Socket), ".socket"); err != nil {
+		err = fmt.Errorf("%w %q: %s", Errors().SocketAccess(), conf.Socket, err)
+		return
+	}
+	defer func() { _ = os.RemoveAll(tmp) }()
+	if name = filepath.Join(tmp, tmpSocketName); len(name) > socketPathMax {
+		name = conf.Socket
+	}
+	if ltn, err = net.Listen(conf.Mode, name); err != nil {
+		return
+	}
+	if ul, ok = ltn.(*net.UnixListener); !ok {
+		_ = ltn.Close()
+		err = fmt.Errorf("%w %q", Errors().SocketNotSocket(), conf.Socket)
+		return
+	}
+	ul.SetUnlinkOnClose(false)
+	if err = socketPermissions(name, mode, uid, gid); err != nil {
+		_ = ul.Close()
+		_ = os.Remove(name)
+		err = fmt.Errorf("%w %q: %s", Errors().SocketPermission(), conf.Socket, err)
+		return
+	}
+	if name != conf.Socket {
+		if err = os.Rename(name, conf.Socket); err != nil {
+			_ = ul.Close()
+			err = fmt.Errorf("%w %q: %s", Errors().SocketAccess(), conf.Socket, err)
+			return
+		}
+	}
+	if fi, err = os.Lstat(conf.Socket); err != nil {
+		_ = ul.Close()
+		err = fmt.Errorf("%w %q: %s", Errors().SocketAccess(), conf.Socket, err)
+		return
+	}
+	ret = &unixSocketListener{
+		UnixListener: ul,
+		path:         conf.Socket,
+		file:         fi,
+		addr:         &net.UnixAddr{Name: conf.Socket, Net: conf.Mode},
+	}
+
+	return
+}
+
+// Addr Адрес слушателя, путь сокета после переноса из временной директории.
+func (usl *unixSocketListener) Addr() net.Addr { return usl.addr }
+
+// Close Закрытие слушателя и удаление файла сокета, если путь указывает на сокет слушателя.
+// Файл удаляется только при первом закрытии, так как номер inode удалённого сокета может быть назначен сокету,
+// открытому на том же пути позже.
+func (usl *unixSocketListener) Close() (err error) {
+	var fi os.FileInfo
+
+	err = usl.UnixListener.Close()
+	usl.once.Do(func() {
+		if fi, _ = os.Lstat(usl.path); fi != nil && os.SameFile(fi, usl.file) {
+			_ = os.Remove(usl.path)
+		}
+	})
+
+	return
+}
+
+// Возвращается истина для юникс-сокета в абстрактном пространстве имён Linux, имя которого начинается с "@".
+func isAbstractSocket(socket string) bool { return strings.HasPrefix(socket, "@") }
+
+// Определение идентификаторов владельца и группы юникс-сокета по имени или числовому значению.
+// Если владелец или группа не указаны, возвращается -1, владелец или группа не изменяются.
+func socketOwnership(conf *Configuration) (uid int, gid int, err error) {
+	var (
+		usr *user.User
+		grp *user.Group
+	)
+
+	uid, gid = -1, -1
+	if conf.SocketOwner != "" {
+		if uid, err = strconv.Atoi(conf.SocketOwner); err != nil {
+			if usr, err = user.Lookup(conf.SocketOwner); err == nil {
+				uid, err = strconv.Atoi(usr.Uid)
+			}
+		}
+		if err != nil {
+			err = fmt.Errorf("%w %q: %s", Errors().SocketOwner(), conf.SocketOwner, err)
+			return
+		}
+	}
+	if conf.SocketGroup != "" {
+		if gid, err = strconv.Atoi(conf.SocketGroup); err != nil {
+			if grp, err = user.LookupGroup(conf.SocketGroup); err == nil {
+				gid, err = strconv.Atoi(grp.Gid)
+			}
+		}
+		if err != nil {
+			err = fmt.Errorf("%w %q: %s", Errors().SocketGroup(), conf.SocketGroup, err)
+			return
+		}
+	}
+
+	return
+}
+
+// Создание директории юникс-сокета, если директория не существует.
+func socketDirectory(conf *Configuration, uid int, gid int) (err error) {
+	var (
+		dir  string
+		mode os.FileMode
+		ui64 uint64
+	)
+
+	if dir = filepath.Dir(conf.Socket); dir == "." || dir == string(filepath.Separator) {
+		return
+	}
+	if _, err = os.Stat(dir); err == nil || !errors.Is(err, os.ErrNotExist) {
+		if err != nil {
+			err = fmt.Errorf("%w %q: %s", Errors().SocketDirectory(), dir, err)
+		}
+		return
+	}
+	if mode = defaultSocketDirectoryMode; conf.SocketDirectoryMode != "" {
+		if ui64, err = strconv.ParseUint(conf.SocketDirectoryMode, 8, 32); err != nil {
+			err = fmt.Errorf(
+				"%w %q, SocketDirectoryMode %q: %s", Errors().SocketDirectory(), dir, conf.SocketDirectoryMode, err,
+			)
+			return
+		}
+		mode = os.FileMode(uint32(ui64))
+	}
+	if err = os.MkdirAll(dir, mode); err == nil {
+		// Разрешения, указанные при создании директории, ограничиваются umask процесса.
+		err = os.Chmod(dir, mode)
+	}
+	if err == nil && (uid >= 0 || gid >= 0) {
+		err = os.Chown(dir, uid, gid)
+	}
+	if err != nil {
+		err = fmt.Errorf("%w %q: %s", Errors().SocketDirectory(), dir, err)
+	}
+
+	return
+}
+
+// Файловые разрешения доступа к юникс-сокету. Не верное значение SocketMode является ошибкой, а не заменяется
+// значением по умолчанию, чтобы сокет не был открыт с более широкими правами доступа, чем требовалось.
+func socketMode(conf *Configuration) (ret os.FileMode, err error) {
+	var ui64 uint64
+
+	if ret = defaultSocketFileMode; conf.SocketMode == "" {
+		return
+	}
+	if ui64, err = strconv.ParseUint(conf.SocketMode, 8, 32); err == nil && ui64&^uint64(os.ModePerm) != 0 {
+		err = strconv.ErrRange
+	}
+	if err != nil {
+		err = fmt.Errorf("%w %q, SocketMode %q: %s", Errors().SocketPermission(), conf.Socket, conf.SocketMode, err)
+		return
+	}
+	ret = os.FileMode(uint32(ui64))
+
+	return
+}
+
+// Установка файловых разрешений доступа, владельца и группы юникс-сокета.
+func socketPermissions(name string, mode os.FileMode, uid int, gid int) (err error) {
+	if err = os.Chmod(name, mode); err == nil && (uid >= 0 || gid >= 0) {
+		err = os.Chown(name, uid, gid)
+	}
+
+	return
+}
@@ -0,0 +1,9 @@
+//go:build linux
+
+package net
+
+// Юникс-сокеты в абстрактном пространстве имён поддерживаются.
+const abstractSocketSupported = true
+
+// Максимальная длина пути юникс-сокета, размер sun_path без завершающего нулевого байта.
+const socketPathMax = 107
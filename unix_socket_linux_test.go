@@ -0,0 +1,53 @@
+//go:build linux
+
+package net
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+)
+
+// Сокет в абстрактном пространстве имён открывается без создания файла и без изменения файловой системы при
+// остановке сервера.
+func TestListenUnixAbstract(t *testing.T) {
+	var (
+		err    error
+		socket string
+		nut    Interface
+	)
+
+	socket = fmt.Sprintf("@webnice-net-test-%d", os.Getpid())
+	nut = New().Handler(testEchoHandler)
+	if err = nut.ListenAndServe("unix:" + socket).Error(); err != nil {
+		t.Fatalf("функция ListenAndServe(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	if addr := nut.Addr(); addr == nil || addr.String() != socket {
+		t.Errorf("функция Addr(), вернулось: %v, ожидалось: %q", addr, socket)
+	}
+	if conf := nut.BoundConfiguration(); conf == nil || conf.Socket != socket {
+		t.Errorf("функция BoundConfiguration(), вернулось: %v, ожидалось: %q", conf, socket)
+	}
+	if ret := testEchoRequest(t, nut.Addr(), "ping"); ret != "ping" {
+		t.Errorf("ответ сервера: %q, ожидалось: %q", ret, "ping")
+	}
+	for _, name := range []string{socket, socket + socketLockSuffix} {
+		if _, err = os.Lstat(name); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("создан файл %q, ошибка: %v", name, err)
+		}
+	}
+	if err = nut.Stop().Error(); err != nil {
+		t.Errorf("функция Stop(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	// После остановки сервера имя освобождается и может быть открыто повторно, владелец сокета не применяется.
+	nut = New().Handler(testEchoHandler)
+	if err = nut.ListenAndServeWithConfig(&Configuration{Mode: netUnix, Socket: socket, SocketOwner: "0"}).
+		Error(); err != nil {
+		t.Fatalf("функция ListenAndServeWithConfig(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer nut.Stop()
+	if ret := testEchoRequest(t, nut.Addr(), "pong"); ret != "pong" {
+		t.Errorf("ответ сервера: %q, ожидалось: %q", ret, "pong")
+	}
+}
@@ -0,0 +1,8 @@
+//go:build !unix
+
+package net
+
+import "os"
+
+// Рекомендательная блокировка файла не поддерживается, проверка сокета выполняется подключением.
+func lockFile(_ *os.File) error { return nil }
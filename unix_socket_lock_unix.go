@@ -0,0 +1,13 @@
+//go:build unix
+
+package net
+
+import (
+	"os"
+	"syscall"
+)
+
+// Захват эксклюзивной рекомендательной блокировки файла без ожидания.
+func lockFile(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+}
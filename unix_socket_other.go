@@ -0,0 +1,11 @@
+//go:build !linux
+
+package net
+
+// Юникс-сокеты в абстрактном пространстве имён поддерживаются только в Linux, в других операционных системах имя
+// "@имя" создало бы обычный файл сокета.
+const abstractSocketSupported = false
+
+// Максимальная длина пути юникс-сокета, размер sun_path без завершающего нулевого байта. Используется наименьший
+// размер среди поддерживаемых операционных систем (104 байта в macOS и BSD).
+const socketPathMax = 103
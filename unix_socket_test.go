@@ -0,0 +1,266 @@
+//go:build unix
+
+package net
+
+import (
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+func TestListenUnixDirectory(t *testing.T) {
+	const dirMode = os.FileMode(0750)
+	var (
+		err    error
+		dir    string
+		socket string
+		ltn    net.Listener
+		fi     os.FileInfo
+	)
+
+	dir = filepath.Join(t.TempDir(), "run", "app")
+	socket = filepath.Join(dir, "app.sock")
+	if ltn, _, err = New().NewListener(&Configuration{
+		Mode:                netUnix,
+		Socket:              socket,
+		SocketMode:          "0660",
+		SocketOwner:         strconv.Itoa(os.Getuid()),
+		SocketGroup:         strconv.Itoa(os.Getgid()),
+		SocketDirectoryMode: "0750",
+	}); err != nil {
+		t.Fatalf("функция NewListener(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer func() { _ = ltn.Close() }()
+	if fi, err = os.Stat(dir); err != nil || fi.Mode().Perm() != dirMode {
+		t.Errorf("директория юникс сокета, Mode(): %v, ошибка: %v, ожидалось: %v", fi.Mode().Perm(), err, dirMode)
+	}
+	if fi, err = os.Stat(socket); err != nil || fi.Mode().Perm() != 0660 {
+		t.Fatalf("юникс сокет, Mode(): %v, ошибка: %v, ожидалось: %v", fi.Mode().Perm(), err, os.FileMode(0660))
+	}
+	if st, ok := fi.Sys().(*syscall.Stat_t); ok && int(st.Gid) != os.Getgid() {
+		t.Errorf("группа юникс сокета: %d, ожидалось: %d", st.Gid, os.Getgid())
+	}
+	if ltn.Addr().String() != socket {
+		t.Errorf("функция Addr(), вернулось: %q, ожидалось: %q", ltn.Addr().String(), socket)
+	}
+	// Временная директория создания сокета удалена.
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("директория юникс сокета, файлов: %d, ожидалось: %d", len(entries), 1)
+	}
+}
+
+// Ошибки определения владельца, группы и создания директории не позволяют открыть сокет.
+func TestListenUnixOwnershipErrors(t *testing.T) {
+	var (
+		err    error
+		dir    string
+		socket string
+	)
+
+	dir = t.TempDir()
+	socket = filepath.Join(dir, "app.sock")
+	var tests = []struct {
+		conf Configuration
+		err  error
+	}{
+		{Configuration{Mode: netUnix, Socket: socket, SocketOwner: "нет-такого-пользователя"}, Errors().SocketOwner()},
+		{Configuration{Mode: netUnix, Socket: socket, SocketGroup: "нет-такой-группы"}, Errors().SocketGroup()},
+		{
+			Configuration{Mode: netUnix, Socket: filepath.Join(dir, "sub", "a.sock"), SocketDirectoryMode: "9"},
+			Errors().SocketDirectory(),
+		},
+		{Configuration{Mode: netUnix, Socket: socket, SocketMode: "0x660"}, Errors().SocketPermission()},
+		{Configuration{Mode: netUnix, Socket: socket, SocketMode: "01777"}, Errors().SocketPermission()},
+	}
+	for n := range tests {
+		if _, _, err = New().NewListener(&tests[n].conf); !errors.Is(err, tests[n].err) {
+			t.Errorf("функция NewListener(), ошибка: %v, ожидалось: %v", err, tests[n].err)
+		}
+		if _, err = os.Stat(tests[n].conf.Socket); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("юникс сокет %q создан, ошибка: %v", tests[n].conf.Socket, err)
+		}
+	}
+}
+
+// Удаляется только юникс-сокет, оставшийся от завершённого процесса, файлы и работающие сокеты не удаляются.
+func TestListenUnixStale(t *testing.T) {
+	var (
+		err    error
+		dir    string
+		socket string
+		ltn    net.Listener
+		live   net.Listener
+	)
+
+	dir = t.TempDir()
+	socket = filepath.Join(dir, "file.sock")
+	if err = os.WriteFile(socket, []byte("data"), 0600); err != nil {
+		t.Fatalf("функция WriteFile(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	_, _, err = New().NewListener(&Configuration{Mode: netUnix, Socket: socket})
+	if !errors.Is(err, Errors().SocketNotSocket()) {
+		t.Errorf("функция NewListener(), ошибка: %v, ожидалось: %v", err, Errors().SocketNotSocket())
+	}
+	if _, err = os.Stat(socket); err != nil {
+		t.Errorf("файл %q удалён, ошибка: %v", socket, err)
+	}
+	if _, err = os.Stat(socket + socketLockSuffix); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("файл блокировки %q не удалён после ошибки, ошибка: %v", socket+socketLockSuffix, err)
+	}
+	// Работающий сокет.
+	socket = filepath.Join(dir, "live.sock")
+	if live, err = net.Listen(netUnix, socket); err != nil {
+		t.Fatalf("функция Listen(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer func() { _ = live.Close() }()
+	_, _, err = New().NewListener(&Configuration{Mode: netUnix, Socket: socket})
+	if !errors.Is(err, Errors().SocketInUse()) {
+		t.Errorf("функция NewListener(), ошибка: %v, ожидалось: %v", err, Errors().SocketInUse())
+	}
+	// Работающий сокет другого типа.
+	_, _, err = New().NewListener(&Configuration{Mode: netUnixPacket, Socket: socket})
+	if !errors.Is(err, Errors().SocketInUse()) {
+		t.Errorf("функция NewListener(), ошибка: %v, ожидалось: %v", err, Errors().SocketInUse())
+	}
+	if conn, e := net.Dial(netUnix, socket); e != nil {
+		t.Errorf("работающий сокет %q недоступен, ошибка: %v", socket, e)
+	} else {
+		_ = conn.Close()
+	}
+	// Сокет, оставшийся от завершённого процесса.
+	socket = filepath.Join(dir, "stale.sock")
+	if ltn, err = net.Listen(netUnix, socket); err != nil {
+		t.Fatalf("функция Listen(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	ltn.(*net.UnixListener).SetUnlinkOnClose(false)
+	_ = ltn.Close()
+	if ltn, _, err = New().NewListener(&Configuration{Mode: netUnix, Socket: socket}); err != nil {
+		t.Fatalf("функция NewListener(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer func() { _ = ltn.Close() }()
+	if ltn.Addr().String() != socket {
+		t.Errorf("функция Addr(), вернулось: %q, ожидалось: %q", ltn.Addr(), socket)
+	}
+	if _, err = os.Stat(socket + socketLockSuffix); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("файл блокировки %q не удалён, ошибка: %v", socket+socketLockSuffix, err)
+	}
+	_ = ltn.Close()
+	if _, err = os.Lstat(socket); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("сокет %q не удалён после закрытия слушателя, ошибка: %v", socket, err)
+	}
+}
+
+// Путь сокета близкий к максимальной длине, путь во временной директории длиннее, сокет открывается сразу на пути
+// сокета с установкой прав доступа.
+func TestListenUnixLongPath(t *testing.T) {
+	const mode = os.FileMode(0600)
+	var (
+		err    error
+		dir    string
+		socket string
+		ltn    net.Listener
+		fi     os.FileInfo
+	)
+
+	dir = t.TempDir()
+	if size := socketPathMax - len(dir) - len("/x/a.sock"); size > 0 {
+		dir = filepath.Join(dir, strings.Repeat("d", size))
+	}
+	if socket = filepath.Join(dir, "a.sock"); len(socket) > socketPathMax {
+		t.Skipf("путь временной директории %q превышает максимальную длину пути юникс-сокета", dir)
+	}
+	ltn, _, err = New().NewListener(&Configuration{Mode: netUnix, Socket: socket, SocketMode: "600"})
+	if err != nil {
+		t.Fatalf("функция NewListener(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	if ltn.Addr().String() != socket {
+		t.Errorf("функция Addr(), вернулось: %q, ожидалось: %q", ltn.Addr(), socket)
+	}
+	if fi, err = os.Lstat(socket); err != nil || fi.Mode().Perm() != mode {
+		t.Errorf("файловые разрешения сокета, ошибка: %v, ожидалось: %s", err, mode)
+	}
+	_ = ltn.Close()
+	if _, err = os.Lstat(socket); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("сокет %q не удалён после закрытия слушателя, ошибка: %v", socket, err)
+	}
+}
+
+// Ошибки доступа к пути сокета и файлу блокировки не считаются использованием сокета другим процессом.
+func TestListenUnixAccessErrors(t *testing.T) {
+	var (
+		err    error
+		socket string
+	)
+
+	socket = filepath.Join(t.TempDir(), "app.sock")
+	if err = os.Mkdir(socket+socketLockSuffix, 0700); err != nil {
+		t.Fatalf("функция Mkdir(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	_, _, err = New().NewListener(&Configuration{Mode: netUnix, Socket: socket})
+	if !errors.Is(err, Errors().SocketAccess()) || errors.Is(err, Errors().SocketInUse()) {
+		t.Errorf("функция NewListener(), ошибка: %v, ожидалось: %v", err, Errors().SocketAccess())
+	}
+}
+
+// Файл блокировки, захваченный другим процессом, означает использование сокета.
+func TestSocketLock(t *testing.T) {
+	var (
+		err     error
+		socket  string
+		lock    *os.File
+		created bool
+	)
+
+	socket = filepath.Join(t.TempDir(), "app.sock")
+	if lock, created, err = socketLock(socket); err != nil || !created {
+		t.Fatalf("функция socketLock(), ошибка: %v, создан: %t, ожидалось: %v, %t", err, created, nil, true)
+	}
+	if _, _, err = socketLock(socket); !errors.Is(err, Errors().SocketInUse()) {
+		t.Errorf("функция socketLock(), ошибка: %v, ожидалось: %v", err, Errors().SocketInUse())
+	}
+	socketUnlock(lock, true)
+	if _, err = os.Stat(socket + socketLockSuffix); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("файл блокировки не удалён, ошибка: %v", err)
+	}
+	if lock, _, err = socketLock(socket); err != nil {
+		t.Fatalf("функция socketLock(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	socketUnlock(lock, false)
+	// Файл блокировки, оставшийся от завершённого процесса, не считается созданным текущим процессом.
+	if lock, created, err = socketLock(socket); err != nil || created {
+		t.Fatalf("функция socketLock(), ошибка: %v, создан: %t, ожидалось: %v, %t", err, created, nil, false)
+	}
+	socketUnlock(lock, false)
+}
+
+// Остановка сервера не удаляет сокет, открытый по тому же пути другим процессом.
+func TestListenUnixStopForeignSocket(t *testing.T) {
+	var (
+		err     error
+		socket  string
+		nut     Interface
+		foreign net.Listener
+	)
+
+	socket = filepath.Join(t.TempDir(), "app.sock")
+	nut = New().Handler(testEchoHandler)
+	if err = nut.ListenAndServeWithConfig(&Configuration{Mode: netUnix, Socket: socket}).Error(); err != nil {
+		t.Fatalf("функция ListenAndServeWithConfig(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	if err = os.Remove(socket); err != nil {
+		t.Fatalf("функция Remove(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	if foreign, err = net.Listen(netUnix, socket); err != nil {
+		t.Fatalf("функция Listen(), ошибка: %v, ожидалось: %v", err, nil)
+	}
+	defer func() { _ = foreign.Close() }()
+	nut.Stop()
+	if _, err = os.Lstat(socket); err != nil {
+		t.Errorf("сокет другого процесса удалён, ошибка: %v", err)
+	}
+}
@@ -1,12 +1,17 @@
 package net
 
 import (
+	"errors"
 	"fmt"
 	"net"
 	"os"
 	runtimeDebug "runtime/debug"
 	"strconv"
 	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pires/go-proxyproto"
 )
 
 // Наполнение конфигурации значениями по умолчанию.
@@ -53,34 +58,45 @@ func parseFileModeWithDefault(mode string) (ret os.FileMode) {
 }
 
 // Разбор адреса, определение порта через net.LookupPort, в том числе портов заданных через синонимы,
-// например ":http".
+// например ":http". Адрес может быть указан в формате URL со схемой, формат описан в parseAddressURL.
 func parseAddress(addr string, mode string) (ret *Configuration, err error) {
-	const bColon = ":"
-	var (
-		sp []string
-		n  int
-	)
+	var host, port string
 
-	addr = strings.TrimSpace(addr)
-	ret, sp = new(Configuration), make([]string, 2)
+	if addr = strings.TrimSpace(addr); addressScheme(addr) != "" {
+		return parseAddressURL(addr)
+	}
+	ret = new(Configuration)
 	defer defaultConfiguration(ret)
 	if mode != "" {
 		ret.Mode = mode
 	}
-	if sp[0], sp[1], err = net.SplitHostPort(addr); err != nil {
+	if host, port, err = net.SplitHostPort(addr); err != nil {
 		ret.Host, err = addr, nil
 		return
 	}
-	if n, err = net.LookupPort(netTcp, strings.Join(sp[1:], bColon)); err != nil {
-		return
-	}
-	ret.Host, ret.Port = sp[0], uint16(n)
-	switch sp, err = net.LookupHost(sp[0]); err {
-	case nil:
-		ret.Host = sp[0]
+	err = lookupHostPort(ret, host, port)
+
+	return
+}
+
+// Определение порта через net.LookupPort с учётом типа соединения (TCP или UDP).
+// Имя хоста сохраняется как есть, разрешение имени выполняется при открытии порта, согласно HostResolve.
+func lookupHostPort(conf *Configuration, host string, port string) (err error) {
+	var (
+		network string
+		n       int
+	)
+
+	switch strings.ToLower(conf.Mode) {
+	case netUdp, netUdp4, netUdp6:
+		network = netUdp
 	default:
-		err = nil
+		network = netTcp
+	}
+	if n, err = net.LookupPort(network, port); err != nil {
+		return
 	}
+	conf.Host, conf.Port = host, uint16(n)
 
 	return
 }
@@ -120,3 +136,54 @@ func safeSendSignal(ch chan struct{}) {
 	defer func() { _ = recover() }()
 	ch <- struct{}{}
 }
+
+// Получение соединения, обёрнутого в переданное соединение (tls.Conn, proxyproto.Conn, соединения пакета).
+// Возвращается nil, если соединение не является обёрткой.
+func unwrapConn(conn net.Conn) (ret net.Conn) {
+	switch c := conn.(type) {
+	case interface{ NetConn() net.Conn }:
+		ret = c.NetConn()
+	case interface{ Raw() net.Conn }:
+		ret = c.Raw()
+	}
+
+	return
+}
+
+// Получение слушателя, обёрнутого в переданный слушатель (слушатели TLS, прокси-протокола, опций сокета).
+// Возвращается nil, если слушатель не является обёрткой.
+func unwrapListener(ltn net.Listener) (ret net.Listener) {
+	switch l := ltn.(type) {
+	case *tlsListener:
+		if ret = l.raw; ret == nil {
+			ret = l.Listener
+		}
+	case *handshakeListener:
+		ret = l.Listener
+	case *proxyproto.Listener:
+		ret = l.Listener
+	case *noDelayListener:
+		ret = l.Listener
+	case *tcpInfoListener:
+		ret = l.Listener
+	case *unixSocketListener:
+		ret = l.UnixListener
+	case *peerListener:
+		ret = l.Listener
+	}
+
+	return
+}
+
+// Задержка повтора приёма соединения после ошибки. Повтор выполняется только для ошибок нехватки ресурсов
+// (EMFILE, ENFILE, ENOBUFS) и разрыва соединения до его приёма (ECONNABORTED), задержка нарастает так же, как в
+// net/http.Server. Для остальных ошибок возвращается ложь.
+func acceptRetryDelay(err error, delay time.Duration) (ret time.Duration, ok bool) {
+	switch {
+	case errors.Is(err, syscall.EMFILE), errors.Is(err, syscall.ENFILE),
+		errors.Is(err, syscall.ENOBUFS), errors.Is(err, syscall.ECONNABORTED):
+		ret, ok = min(max(delay*2, acceptRetryDelayMin), acceptRetryDelayMax), true
+	}
+
+	return
+}